@@ -15,17 +15,26 @@
 package gomock
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"runtime"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Call represents an expected call to a mock.
 type Call struct {
-	t TestHelper // for triggering test failures on invalid call setup
+	t    TestHelper  // for triggering test failures on invalid call setup
+	ctrl *Controller // the controller that recorded the call, if any
 
 	receiver   interface{}  // the receiver of the method call
+	name       string       // the name given to the receiver, if any
 	method     string       // the name of the method
 	methodType reflect.Type // the type of the method
 	args       []Matcher    // the args
@@ -33,11 +42,55 @@ type Call struct {
 
 	preReqs []*Call // prerequisite calls
 
+	// argAsserts are checked against all the args once each of them matches.
+	argAsserts []func([]interface{}) error
+
+	// requireWritten are the indexes of the args whose pointees the actions
+	// must change.
+	requireWritten []int
+
+	// strictSequence makes matches beyond the values of ReturnSequence fail.
+	strictSequence bool
+
+	// histograms tally the values of args across matches for Finish to check.
+	histograms []*argHistogram
+
 	// Expectations
 	minCalls, maxCalls int
+	timesRange         bool // whether the bounds were set by TimesRange
 
 	numCalls int // actual number made
 
+	// Sequence numbers among all matched calls of the controller of the first
+	// and last match of this call, or zero if it has not been matched.
+	firstMatch, lastMatch int
+
+	// onSatisfied are called once numCalls reaches a non-zero minCalls.
+	onSatisfied []func()
+
+	// If non-zero, a warning is logged for matches later than within after
+	// setupTime.
+	within    time.Duration
+	setupTime time.Time
+
+	// If non-zero, consecutive matches must be at least minInterval apart.
+	// lastMatchTime is the time of the last match.
+	minInterval   time.Duration
+	lastMatchTime time.Time
+
+	// If recordIntervals is set, intervals holds the time between each pair
+	// of consecutive matches.
+	recordIntervals bool
+	intervals       []time.Duration
+
+	// If non-zero, each action must return within actionTimeout.
+	actionTimeout time.Duration
+
+	// If goroutineCheck is not goroutineAny, matches are restricted to or
+	// from the goroutine with ID setupGoroutine.
+	goroutineCheck int
+	setupGoroutine uint64
+
 	// actions are called when this Call is called. Each action gets the args and
 	// can set the return values by returning a non-nil slice. Actions run in the
 	// order they are created.
@@ -79,9 +132,55 @@ func newCall(t TestHelper, receiver interface{}, method string, methodType refle
 // AnyTimes allows the expectation to be called 0 or more times
 func (c *Call) AnyTimes() *Call {
 	c.minCalls, c.maxCalls = 0, 1e8 // close enough to infinity
+	c.timesRange = false
 	return c
 }
 
+// Wait blocks until the call has been matched at least n times, and returns
+// true. If the Controller was created by WithContext, Wait returns false once
+// its context is done, e.g. because of a fatal failure in another goroutine.
+// It lets a test synchronize with goroutines that call its mocks.
+func (c *Call) Wait(n int) bool {
+	c.t.Helper()
+
+	ctrl := c.ctrl
+	if ctrl == nil {
+		c.t.Fatalf("Wait called for %T.%v, which was not recorded by a Controller [%s]", c.receiver, c.method, c.origin)
+		return false
+	}
+
+	if ctx := ctrl.ctx; ctx != nil {
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			select {
+			case <-ctx.Done():
+				ctrl.mu.Lock()
+				defer ctrl.mu.Unlock()
+				ctrl.matched.Broadcast()
+			case <-stop:
+			}
+		}()
+	}
+
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+	for c.numCalls < n {
+		if ctrl.ctx != nil && ctrl.ctx.Err() != nil {
+			return false
+		}
+		ctrl.matched.Wait()
+	}
+	return true
+}
+
+// Optional allows the expectation to be called 0 or more times, so that
+// Finish never reports it as missing. It is equivalent to AnyTimes, but
+// states the intent that the call may not happen at all.
+func (c *Call) Optional() *Call {
+	return c.AnyTimes()
+}
+
 // MinTimes requires the call to occur at least n times. If AnyTimes or MaxTimes have not been called or if MaxTimes
 // was previously called with 1, MinTimes also sets the maximum number of calls to infinity.
 func (c *Call) MinTimes(n int) *Call {
@@ -89,6 +188,7 @@ func (c *Call) MinTimes(n int) *Call {
 	if c.maxCalls == 1 {
 		c.maxCalls = 1e8
 	}
+	c.timesRange = false
 	return c
 }
 
@@ -99,6 +199,7 @@ func (c *Call) MaxTimes(n int) *Call {
 	if c.minCalls == 1 {
 		c.minCalls = 0
 	}
+	c.timesRange = false
 	return c
 }
 
@@ -106,8 +207,13 @@ func (c *Call) MaxTimes(n int) *Call {
 // The return values from this function are returned by the mocked function.
 // It takes an interface{} argument to support n-arity functions.
 func (c *Call) DoAndReturn(f interface{}) *Call {
-	// TODO: Check arity and types here, rather than dying badly elsewhere.
+	c.t.Helper()
+
+	if !c.checkDoFunc("DoAndReturn", f, true) {
+		return c
+	}
 	v := reflect.ValueOf(f)
+	mt := c.methodType
 
 	c.addAction(func(args []interface{}) []interface{} {
 		vargs := make([]reflect.Value, len(args))
@@ -115,14 +221,24 @@ func (c *Call) DoAndReturn(f interface{}) *Call {
 		for i := 0; i < len(args); i++ {
 			if args[i] != nil {
 				vargs[i] = reflect.ValueOf(args[i])
+			} else if ft.IsVariadic() && i >= ft.NumIn()-1 {
+				vargs[i] = reflect.Zero(ft.In(ft.NumIn() - 1).Elem())
 			} else {
 				// Use the zero value for the arg.
 				vargs[i] = reflect.Zero(ft.In(i))
 			}
 		}
-		vrets := v.Call(vargs)
+		vrets := c.timeCallback(v, vargs)
 		rets := make([]interface{}, len(vrets))
 		for i, ret := range vrets {
+			if want := mt.Out(i); ret.Type() != want {
+				// Convert values of assignable types, such as concrete types
+				// implementing an interface, so that the generated code can
+				// return them with a type assertion.
+				converted := reflect.New(want).Elem()
+				converted.Set(ret)
+				ret = converted
+			}
 			rets[i] = ret.Interface()
 		}
 		return rets
@@ -130,12 +246,98 @@ func (c *Call) DoAndReturn(f interface{}) *Call {
 	return c
 }
 
+// checkDoFunc reports whether f, as passed to the Call method named by
+// caller, is a function that can be called with the arguments of the method
+// and, if results is set, whose results can be returned by it. If not, it
+// calls setupFailed. Without results, as for Do, the element type of a
+// variadic parameter is not checked, since the variadic arguments are passed
+// to f one by one and only their dynamic types need to fit.
+func (c *Call) checkDoFunc(caller string, f interface{}, results bool) bool {
+	c.t.Helper()
+
+	mt, ft := c.methodType, reflect.TypeOf(f)
+	if ft == nil || ft.Kind() != reflect.Func {
+		c.setupFailed("argument to %s for %T.%v is %T, not a function [%s]",
+			caller, c.receiver, c.method, f, c.origin)
+		return false
+	}
+	if ft.NumIn() != mt.NumIn() || ft.IsVariadic() != mt.IsVariadic() {
+		c.setupFailed("wrong signature of function passed to %s for %T.%v: %v does not take the arguments of %v [%s]",
+			caller, c.receiver, c.method, ft, mt, c.origin)
+		return false
+	}
+	for i := 0; i < mt.NumIn(); i++ {
+		if !results && mt.IsVariadic() && i == mt.NumIn()-1 {
+			continue
+		}
+		if !mt.In(i).AssignableTo(ft.In(i)) {
+			c.setupFailed("wrong type of argument %d of function passed to %s for %T.%v: %v is not assignable to %v%s [%s]",
+				i, caller, c.receiver, c.method, mt.In(i), ft.In(i), chanMismatch(mt.In(i), ft.In(i)), c.origin)
+			return false
+		}
+	}
+	if !results {
+		return true
+	}
+	if ft.NumOut() != mt.NumOut() {
+		c.setupFailed("wrong number of return values of function passed to %s for %T.%v: got %d, want %d [%s]",
+			caller, c.receiver, c.method, ft.NumOut(), mt.NumOut(), c.origin)
+		return false
+	}
+	for i := 0; i < mt.NumOut(); i++ {
+		if !ft.Out(i).AssignableTo(mt.Out(i)) {
+			c.setupFailed("wrong type of return value %d of function passed to %s for %T.%v: %v is not assignable to %v%s [%s]",
+				i, caller, c.receiver, c.method, ft.Out(i), mt.Out(i), chanMismatch(ft.Out(i), mt.Out(i)), c.origin)
+			return false
+		}
+	}
+	return true
+}
+
+// chanMismatch explains why a value of channel type from is not assignable to
+// channel type to, for checkDoFunc. A bidirectional channel may be passed as a
+// directional one, but not the other way around, since the direction of a
+// channel cannot be widened. It returns "" if either type is not a channel.
+func chanMismatch(from, to reflect.Type) string {
+	if from.Kind() != reflect.Chan || to.Kind() != reflect.Chan {
+		return ""
+	}
+	switch {
+	case from.Elem() != to.Elem():
+		return fmt.Sprintf(" (channel element type %v differs from %v)", from.Elem(), to.Elem())
+	case from.ChanDir() != reflect.BothDir && to.ChanDir() == reflect.BothDir:
+		return " (a directional channel cannot be used as a bidirectional one)"
+	case from.ChanDir() != to.ChanDir() && to.ChanDir() != reflect.BothDir:
+		return " (channel directions differ)"
+	}
+	return ""
+}
+
+// setupFailed fails the test because of a problem with how the call is set
+// up. If the call's Controller was created with WithDryRun, the problem is
+// instead recorded for Validate to return.
+func (c *Call) setupFailed(format string, args ...interface{}) {
+	c.t.Helper()
+
+	if ctrl := c.ctrl; ctrl != nil && ctrl.dryRun {
+		ctrl.mu.Lock()
+		defer ctrl.mu.Unlock()
+		ctrl.problems = append(ctrl.problems, fmt.Sprintf(format, args...))
+		return
+	}
+	c.t.Fatalf(format, args...)
+}
+
 // Do declares the action to run when the call is matched. The function's
 // return values are ignored to retain backward compatibility. To use the
 // return values call DoAndReturn.
 // It takes an interface{} argument to support n-arity functions.
 func (c *Call) Do(f interface{}) *Call {
-	// TODO: Check arity and types here, rather than dying badly elsewhere.
+	c.t.Helper()
+
+	if !c.checkDoFunc("Do", f, false) {
+		return c
+	}
 	v := reflect.ValueOf(f)
 
 	c.addAction(func(args []interface{}) []interface{} {
@@ -149,7 +351,69 @@ func (c *Call) Do(f interface{}) *Call {
 				vargs[i] = reflect.Zero(ft.In(i))
 			}
 		}
-		v.Call(vargs)
+		c.timeCallback(v, vargs)
+		return nil
+	})
+	return c
+}
+
+// DoWithContext declares an action to run when the call is matched, like Do,
+// for a method whose first argument is a context.Context. f is called with
+// just that context; the other arguments are ignored. DoWithContext fails the
+// test if the method's first argument is not a context.
+//
+// Example usage:
+//   mock.EXPECT().Load(gomock.Any(), "key").DoWithContext(func(ctx context.Context) {
+//     <-ctx.Done()
+//   }).Return("", context.Canceled)
+func (c *Call) DoWithContext(f func(ctx context.Context)) *Call {
+	c.t.Helper()
+
+	mt := c.methodType
+	if mt.NumIn() == 0 || !mt.In(0).Implements(contextType) {
+		c.setupFailed("DoWithContext called for %T.%v, whose first argument is not a context.Context [%s]",
+			c.receiver, c.method, c.origin)
+		return c
+	}
+
+	v := reflect.ValueOf(f)
+	c.addAction(func(args []interface{}) []interface{} {
+		ctx := reflect.Zero(contextType)
+		if args[0] != nil {
+			ctx = reflect.ValueOf(args[0])
+		}
+		c.timeCallback(v, []reflect.Value{ctx})
+		return nil
+	})
+	return c
+}
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// timeCallback calls f, a function given to Do or DoAndReturn, with args and
+// reports how long it took to the Controller's callback timer, if it has one.
+func (c *Call) timeCallback(f reflect.Value, args []reflect.Value) []reflect.Value {
+	if c.ctrl == nil || c.ctrl.timeCallback == nil {
+		return f.Call(args)
+	}
+	start := time.Now()
+	defer func() {
+		c.ctrl.timeCallback(c.method, time.Since(start))
+	}()
+	return f.Call(args)
+}
+
+// NotifyOn declares that each time the call matches, a value is sent on ch,
+// like an action added by Do. The send never blocks the mock: if ch is not
+// ready to receive, the notification is dropped. To receive every
+// notification, give ch a buffer at least as large as the number of matches
+// that may happen before it is read.
+func (c *Call) NotifyOn(ch chan<- struct{}) *Call {
+	c.addAction(func([]interface{}) []interface{} {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
 		return nil
 	})
 	return c
@@ -159,10 +423,30 @@ func (c *Call) Do(f interface{}) *Call {
 func (c *Call) Return(rets ...interface{}) *Call {
 	c.t.Helper()
 
+	if !c.checkReturns("Return", rets) {
+		return c
+	}
+
+	c.addAction(func([]interface{}) []interface{} {
+		return rets
+	})
+
+	return c
+}
+
+// checkReturns reports whether rets are valid return values for the method,
+// as passed to the Call method named by caller. If not, it calls setupFailed.
+// Values of a type that is assignable to, but not identical to, the return
+// type are converted in place so that the generated code can return them with
+// a type assertion.
+func (c *Call) checkReturns(caller string, rets []interface{}) bool {
+	c.t.Helper()
+
 	mt := c.methodType
 	if len(rets) != mt.NumOut() {
-		c.t.Fatalf("wrong number of arguments to Return for %T.%v: got %d, want %d [%s]",
-			c.receiver, c.method, len(rets), mt.NumOut(), c.origin)
+		c.setupFailed("wrong number of arguments to %s for %T.%v: got %d, want %d [%s]",
+			caller, c.receiver, c.method, len(rets), mt.NumOut(), c.origin)
+		return false
 	}
 	for i, ret := range rets {
 		if got, want := reflect.TypeOf(ret), mt.Out(i); got == want {
@@ -173,8 +457,9 @@ func (c *Call) Return(rets ...interface{}) *Call {
 			case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
 				// ok
 			default:
-				c.t.Fatalf("argument %d to Return for %T.%v is nil, but %v is not nillable [%s]",
-					i, c.receiver, c.method, want, c.origin)
+				c.setupFailed("argument %d to %s for %T.%v is nil, but %v is not nillable [%s]",
+					i, caller, c.receiver, c.method, want, c.origin)
+				return false
 			}
 		} else if got.AssignableTo(want) {
 			// Assignable type relation. Make the assignment now so that the generated code
@@ -183,24 +468,402 @@ func (c *Call) Return(rets ...interface{}) *Call {
 			v.Set(reflect.ValueOf(ret))
 			rets[i] = v.Interface()
 		} else {
-			c.t.Fatalf("wrong type of argument %d to Return for %T.%v: %v is not assignable to %v [%s]",
-				i, c.receiver, c.method, got, want, c.origin)
+			c.setupFailed("wrong type of argument %d to %s for %T.%v: %v is not assignable to %v [%s]",
+				i, caller, c.receiver, c.method, got, want, c.origin)
+			return false
 		}
 	}
+	return true
+}
+
+// FailNThenSucceed declares that the call is expected exactly n+1 times, as
+// is typical for code that retries. The first n calls return err as the last
+// return value, which must be of type error, and the zero value for every
+// other return value. The final call returns the success values.
+func (c *Call) FailNThenSucceed(n int, err error, success ...interface{}) *Call {
+	c.t.Helper()
 
+	mt := c.methodType
+	if n < 0 {
+		c.setupFailed("FailNThenSucceed(%d, ...) called with a negative number of failures [%s]", n, c.origin)
+		return c
+	}
+	if mt.NumOut() == 0 || mt.Out(mt.NumOut()-1) != reflect.TypeOf((*error)(nil)).Elem() {
+		c.setupFailed("FailNThenSucceed called for %T.%v, whose last return value is not an error [%s]",
+			c.receiver, c.method, c.origin)
+		return c
+	}
+	if !c.checkReturns("FailNThenSucceed", success) {
+		return c
+	}
+
+	failure := make([]interface{}, mt.NumOut())
+	for i := 0; i < mt.NumOut()-1; i++ {
+		failure[i] = reflect.Zero(mt.Out(i)).Interface()
+	}
+	failure[mt.NumOut()-1] = err
+
+	var mu sync.Mutex
+	calls := 0
 	c.addAction(func([]interface{}) []interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls <= n {
+			return failure
+		}
+		return success
+	})
+
+	return c.Times(n + 1)
+}
+
+// ReturnSequence declares the values to be returned by successive matches of
+// the call: the first match returns the values of the first tuple, the second
+// match those of the second, and so on. Each tuple holds the return values of
+// the method, as passed to Return. Once the sequence is exhausted, further
+// matches return the last tuple again, unless StrictSequence was called.
+//
+// Example usage:
+//   mock.EXPECT().Next().ReturnSequence([]interface{}{1}, []interface{}{2}, []interface{}{3}).Times(3)
+func (c *Call) ReturnSequence(values ...[]interface{}) *Call {
+	c.t.Helper()
+
+	if len(values) == 0 {
+		c.setupFailed("ReturnSequence called for %T.%v without any return values [%s]",
+			c.receiver, c.method, c.origin)
+		return c
+	}
+	for _, rets := range values {
+		if !c.checkReturns("ReturnSequence", rets) {
+			return c
+		}
+	}
+
+	var mu sync.Mutex
+	calls := 0
+	c.addAction(func([]interface{}) []interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls > len(values) {
+			if c.strictSequence {
+				c.t.Fatalf("call to %v exceeded its sequence of %d return values [%s]",
+					c, len(values), c.origin)
+			}
+			return values[len(values)-1]
+		}
+		return values[calls-1]
+	})
+	return c
+}
+
+// StrictSequence declares that matching the call more times than there are
+// values given to ReturnSequence fails the test, instead of returning the
+// last values again.
+func (c *Call) StrictSequence() *Call {
+	c.strictSequence = true
+	return c
+}
+
+// ReturnPtr declares the values to be returned by the mocked function call,
+// given as pointers to them. The pointers are dereferenced each time the call
+// is matched, so changes made to the pointees after setup are observed.
+func (c *Call) ReturnPtr(ptrs ...interface{}) *Call {
+	c.t.Helper()
+
+	mt := c.methodType
+	if len(ptrs) != mt.NumOut() {
+		c.setupFailed("wrong number of arguments to ReturnPtr for %T.%v: got %d, want %d [%s]",
+			c.receiver, c.method, len(ptrs), mt.NumOut(), c.origin)
+		return c
+	}
+	for i, ptr := range ptrs {
+		want := mt.Out(i)
+		pt := reflect.TypeOf(ptr)
+		if pt == nil || pt.Kind() != reflect.Ptr || reflect.ValueOf(ptr).IsNil() {
+			c.setupFailed("argument %d to ReturnPtr for %T.%v is %v, not a non-nil pointer [%s]",
+				i, c.receiver, c.method, pt, c.origin)
+			return c
+		} else if !pt.Elem().AssignableTo(want) {
+			c.setupFailed("wrong type of argument %d to ReturnPtr for %T.%v: %v is not assignable to %v [%s]",
+				i, c.receiver, c.method, pt.Elem(), want, c.origin)
+			return c
+		}
+	}
+
+	c.addAction(func([]interface{}) []interface{} {
+		rets := make([]interface{}, len(ptrs))
+		for i, ptr := range ptrs {
+			v := reflect.New(mt.Out(i)).Elem()
+			v.Set(reflect.ValueOf(ptr).Elem())
+			rets[i] = v.Interface()
+		}
 		return rets
 	})
 
 	return c
 }
 
+// OnSatisfied declares a function to be called once, right after the call is
+// made for the minimum number of times it is expected to be made, e.g. for the
+// second time after Times(2) or MinTimes(2). It is called outside of the
+// Controller's lock, after the call's actions have run. A call that is not
+// required to be made at all, such as one with AnyTimes, is satisfied from the
+// start and so never calls f.
+//
+// Example usage:
+//   var wg sync.WaitGroup
+//   wg.Add(1)
+//   mock.EXPECT().Flush().Times(2).OnSatisfied(wg.Done)
+func (c *Call) OnSatisfied(f func()) *Call {
+	c.onSatisfied = append(c.onSatisfied, f)
+	return c
+}
+
+// AssertArgs declares an assertion about the arguments of the call as a
+// whole, for conditions that span several arguments and so cannot be
+// expressed by a Matcher. It is evaluated only once every argument matcher
+// has matched, with the arguments as passed to the mock method (the elements
+// of a variadic argument are passed individually). If it returns an error,
+// the call does not match, and the error is reported if no other expected
+// call matches either. AssertArgs may be called more than once; every
+// assertion must pass.
+//
+// Example usage:
+//   mock.EXPECT().Copy(gomock.Any(), gomock.Any()).AssertArgs(func(args []interface{}) error {
+//       if args[0] == args[1] {
+//           return errors.New("dst and src must differ")
+//       }
+//       return nil
+//   })
+func (c *Call) AssertArgs(f func(args []interface{}) error) *Call {
+	c.argAsserts = append(c.argAsserts, f)
+	return c
+}
+
+// Forbid declares a combination of arguments that the call must not be made
+// with: like an assertion added by AssertArgs, forbidden is evaluated once every
+// argument matcher has matched, and if it returns true the call does not match.
+//
+// Example usage:
+//   mock.EXPECT().Open(gomock.Any(), gomock.Any()).Forbid(func(args []interface{}) bool {
+//       return args[0] == "/" && args[1] == os.O_RDWR
+//   })
+func (c *Call) Forbid(forbidden func(args []interface{}) bool) *Call {
+	return c.AssertArgs(func(args []interface{}) error {
+		if forbidden(args) {
+			return errors.New("the arguments are a forbidden combination")
+		}
+		return nil
+	})
+}
+
+// ExpectWithin declares a soft time budget for the call: each time it is
+// matched later than d after ExpectWithin was called, a warning is logged.
+// The warning is logged with the TestReporter's Logf method if it has one,
+// like *testing.T, so that it does not fail the test. A TestReporter without
+// Logf never sees the warning: it is written to the standard logger of the
+// log package instead, by default to standard error.
+func (c *Call) ExpectWithin(d time.Duration) *Call {
+	c.within = d
+	c.setupTime = time.Now()
+	return c
+}
+
+// MinInterval declares that consecutive matches of the call must be at least
+// d apart, for code that must not call a dependency too frequently. A match
+// sooner than d after the previous one still matches, but fails the test.
+func (c *Call) MinInterval(d time.Duration) *Call {
+	c.minInterval = d
+	return c
+}
+
+// Within declares that each action of the call, such as a function given to
+// Do or DoAndReturn, must return within d of the call being matched. An action
+// that takes longer fails the test fatally, rather than hanging it. If the
+// TestReporter's Fatalf returns, the action's results are replaced with the
+// zero values of the method's results.
+//
+// If the method's first parameter is a context.Context, each action is given a
+// context derived from the argument, which is canceled once the action returns
+// or times out, so that actions waiting on it, such as those added by
+// DoWithContext, stop. Other actions are abandoned when they time out: the
+// goroutine running them continues until they return, if ever, and their
+// return values are then discarded.
+func (c *Call) Within(d time.Duration) *Call {
+	c.actionTimeout = d
+	return c
+}
+
+// runAction runs action with args, subject to the timeout set by Within.
+func (c *Call) runAction(action func([]interface{}) []interface{}, args []interface{}) []interface{} {
+	c.t.Helper()
+
+	if c.actionTimeout <= 0 {
+		return action(args)
+	}
+
+	if mt := c.methodType; mt.NumIn() > 0 && mt.In(0) == contextType && len(args) > 0 {
+		parent, _ := args[0].(context.Context)
+		if parent == nil {
+			parent = context.Background()
+		}
+		ctx, cancel := context.WithCancel(parent)
+		defer cancel()
+		args = append([]interface{}{ctx}, args[1:]...)
+	}
+
+	type result struct {
+		rets  []interface{}
+		panic interface{}
+	}
+	// The channel is buffered so that an action returning after the timeout
+	// does not block its goroutine forever.
+	done := make(chan result, 1)
+	go func() {
+		var r result
+		defer func() {
+			if r.panic = recover(); r.panic != nil {
+				done <- r
+			}
+		}()
+		r.rets = action(args)
+		done <- r
+	}()
+
+	timer := time.NewTimer(c.actionTimeout)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		if r.panic != nil {
+			panic(r.panic)
+		}
+		return r.rets
+	case <-timer.C:
+		c.t.Fatalf("action of call to %T.%v did not return within %v [%s]",
+			c.receiver, c.method, c.actionTimeout, c.origin)
+		// Fatalf may return, e.g. when the mock is called from a goroutine
+		// other than the test's, and generated mocks index their results.
+		mt := c.methodType
+		rets := make([]interface{}, mt.NumOut())
+		for i := range rets {
+			rets[i] = reflect.Zero(mt.Out(i)).Interface()
+		}
+		return rets
+	}
+}
+
+// RecordIntervals declares that the time between consecutive matches of the
+// call is to be recorded, for Intervals to return. This lets tests check the
+// timing of retries, such as an exponential backoff.
+func (c *Call) RecordIntervals() *Call {
+	c.recordIntervals = true
+	return c
+}
+
+// Intervals returns the time between each pair of consecutive matches of the
+// call so far, in order, as recorded since RecordIntervals was called. A call
+// matched n times thus has n-1 intervals.
+func (c *Call) Intervals() []time.Duration {
+	if c.ctrl != nil {
+		c.ctrl.mu.Lock()
+		defer c.ctrl.mu.Unlock()
+	}
+
+	intervals := make([]time.Duration, len(c.intervals))
+	copy(intervals, c.intervals)
+	return intervals
+}
+
+// FromSameGoroutine declares that the call only matches when it is made from
+// the goroutine that called FromSameGoroutine, usually the one setting up the
+// expectation.
+//
+// Go does not expose goroutine IDs, so they are parsed from the output of
+// runtime.Stack, whose format is not guaranteed to stay the same across Go
+// versions. Prefer to synchronize explicitly where possible.
+func (c *Call) FromSameGoroutine() *Call {
+	c.goroutineCheck = goroutineSame
+	c.setupGoroutine = goroutineID()
+	return c
+}
+
+// FromDifferentGoroutine declares that the call only matches when it is made
+// from a goroutine other than the one that called FromDifferentGoroutine. It
+// shares the caveats of FromSameGoroutine.
+func (c *Call) FromDifferentGoroutine() *Call {
+	c.goroutineCheck = goroutineDifferent
+	c.setupGoroutine = goroutineID()
+	return c
+}
+
+// warnIfLate logs a warning if the call is matched after its time budget
+// declared by ExpectWithin.
+func (c *Call) warnIfLate() {
+	if c.within <= 0 {
+		return
+	}
+	if elapsed := time.Since(c.setupTime); elapsed > c.within {
+		logf(c.t, "call to %v arrived %v after setup, later than the expected %v", c, elapsed, c.within)
+	}
+}
+
+// checkInterval records that the call was matched at now, along with the
+// interval since the previous match if RecordIntervals was called, and returns
+// an error if that was sooner after the previous match than allowed by
+// MinInterval.
+func (c *Call) checkInterval(now time.Time) error {
+	if c.minInterval <= 0 && !c.recordIntervals {
+		return nil
+	}
+	last := c.lastMatchTime
+	c.lastMatchTime = now
+	if last.IsZero() {
+		return nil
+	}
+	interval := now.Sub(last)
+	if c.recordIntervals {
+		c.intervals = append(c.intervals, interval)
+	}
+	if c.minInterval > 0 && interval < c.minInterval {
+		return fmt.Errorf("call to %v arrived %v after the previous one, sooner than the minimum interval of %v",
+			c, interval, c.minInterval)
+	}
+	return nil
+}
+
 // Times declares the exact number of times a function call is expected to be executed.
 func (c *Call) Times(n int) *Call {
 	c.minCalls, c.maxCalls = n, n
+	c.timesRange = false
 	return c
 }
 
+// TimesRange requires the call to occur at least min and at most max times.
+// It is like MinTimes(min).MaxTimes(max), but does not depend on the order of
+// the calls, and failures report the range.
+func (c *Call) TimesRange(min, max int) *Call {
+	c.t.Helper()
+
+	if min < 0 || min > max {
+		c.setupFailed("TimesRange(%d, %d) called with an invalid range [%s]", min, max, c.origin)
+		return c
+	}
+	c.minCalls, c.maxCalls = min, max
+	c.timesRange = true
+	return c
+}
+
+// countMismatch describes how n calls are out of the range set by
+// TimesRange, if it was used; otherwise it returns "".
+func (c *Call) countMismatch(n int) string {
+	if !c.timesRange {
+		return ""
+	}
+	return fmt.Sprintf(": expected between %d and %d calls, got %d", c.minCalls, c.maxCalls, n)
+}
+
 // SetArg declares an action that will set the nth argument's value,
 // indirected through a pointer. Or, in the case of a slice, SetArg
 // will copy value's elements into the nth argument.
@@ -211,8 +874,9 @@ func (c *Call) SetArg(n int, value interface{}) *Call {
 	// TODO: This will break on variadic methods.
 	// We will need to check those at invocation time.
 	if n < 0 || n >= mt.NumIn() {
-		c.t.Fatalf("SetArg(%d, ...) called for a method with %d args [%s]",
+		c.setupFailed("SetArg(%d, ...) called for a method with %d args [%s]",
 			n, mt.NumIn(), c.origin)
+		return c
 	}
 	// Permit setting argument through an interface.
 	// In the interface case, we don't (nay, can't) check the type here.
@@ -221,16 +885,18 @@ func (c *Call) SetArg(n int, value interface{}) *Call {
 	case reflect.Ptr:
 		dt := at.Elem()
 		if vt := reflect.TypeOf(value); !vt.AssignableTo(dt) {
-			c.t.Fatalf("SetArg(%d, ...) argument is a %v, not assignable to %v [%s]",
+			c.setupFailed("SetArg(%d, ...) argument is a %v, not assignable to %v [%s]",
 				n, vt, dt, c.origin)
+			return c
 		}
 	case reflect.Interface:
 		// nothing to do
 	case reflect.Slice:
 		// nothing to do
 	default:
-		c.t.Fatalf("SetArg(%d, ...) referring to argument of non-pointer non-interface non-slice type %v [%s]",
+		c.setupFailed("SetArg(%d, ...) referring to argument of non-pointer non-interface non-slice type %v [%s]",
 			n, at, c.origin)
+		return c
 	}
 
 	c.addAction(func(args []interface{}) []interface{} {
@@ -261,10 +927,12 @@ func (c *Call) After(preReq *Call) *Call {
 	c.t.Helper()
 
 	if c == preReq {
-		c.t.Fatalf("A call isn't allowed to be its own prerequisite")
+		c.setupFailed("A call isn't allowed to be its own prerequisite")
+		return c
 	}
 	if preReq.isPreReq(c) {
-		c.t.Fatalf("Loop in call order: %v is a prerequisite to %v (possibly indirectly).", c, preReq)
+		c.setupFailed("Loop in call order: %v is a prerequisite to %v (possibly indirectly).", c, preReq)
+		return c
 	}
 
 	c.preReqs = append(c.preReqs, preReq)
@@ -276,6 +944,15 @@ func (c *Call) satisfied() bool {
 	return c.numCalls >= c.minCalls
 }
 
+// justSatisfied returns the functions declared by OnSatisfied if the last
+// call made this Call satisfied.
+func (c *Call) justSatisfied() []func() {
+	if c.minCalls > 0 && c.numCalls == c.minCalls {
+		return c.onSatisfied
+	}
+	return nil
+}
+
 // Returns true if the maximum number of calls have been made.
 func (c *Call) exhausted() bool {
 	return c.numCalls >= c.maxCalls
@@ -287,11 +964,28 @@ func (c *Call) String() string {
 		args[i] = arg.String()
 	}
 	arguments := strings.Join(args, ", ")
+	if c.name != "" {
+		return fmt.Sprintf("%s.%v(%s) %s", c.name, c.method, arguments, c.origin)
+	}
 	return fmt.Sprintf("%T.%v(%s) %s", c.receiver, c.method, arguments, c.origin)
 }
 
 // Tests if the given call matches the expected call.
 // If yes, returns nil. If no, returns error with message explaining why it does not match.
+// otherGots describes the arguments other than the one at index skip whose
+// matchers are GotFormatters, such as those matched by Any, for the failure
+// message of a mismatch. Only the first n matchers, which must correspond to
+// args one to one, are considered.
+func (c *Call) otherGots(args []interface{}, skip, n int) string {
+	var others strings.Builder
+	for j, m := range c.args[:n] {
+		if gs, ok := m.(GotFormatter); ok && j != skip {
+			fmt.Fprintf(&others, "\nGot at index %d: %s", j, gs.Got(args[j]))
+		}
+	}
+	return others.String()
+}
+
 func (c *Call) matches(args []interface{}) error {
 	if !c.methodType.IsVariadic() {
 		if len(args) != len(c.args) {
@@ -301,14 +995,13 @@ func (c *Call) matches(args []interface{}) error {
 
 		for i, m := range c.args {
 			if !m.Matches(args[i]) {
-				got := fmt.Sprintf("%v", args[i])
+				got := formatValue(args[i])
 				if gs, ok := m.(GotFormatter); ok {
 					got = gs.Got(args[i])
 				}
-
 				return fmt.Errorf(
-					"expected call at %s doesn't match the argument at index %d.\nGot: %v\nWant: %v",
-					c.origin, i, got, m,
+					"expected call at %s doesn't match the argument at index %d.\nGot: %v\nWant: %v%s",
+					c.origin, i, got, m, c.otherGots(args, i, len(c.args)),
 				)
 			}
 		}
@@ -326,12 +1019,22 @@ func (c *Call) matches(args []interface{}) error {
 				c.origin, len(args), len(c.args)-1)
 		}
 
+		// Only the matchers of the non-variadic args correspond to args one
+		// to one, unless there is a matcher for each variadic arg.
+		oneToOne := c.methodType.NumIn() - 1
+		if len(c.args) == len(args) {
+			oneToOne = len(c.args)
+		}
 		for i, m := range c.args {
 			if i < c.methodType.NumIn()-1 {
 				// Non-variadic args
 				if !m.Matches(args[i]) {
-					return fmt.Errorf("expected call at %s doesn't match the argument at index %s.\nGot: %v\nWant: %v",
-						c.origin, strconv.Itoa(i), args[i], m)
+					got := formatValue(args[i])
+					if gs, ok := m.(GotFormatter); ok {
+						got = gs.Got(args[i])
+					}
+					return fmt.Errorf("expected call at %s doesn't match the argument at index %s.\nGot: %s\nWant: %v%s",
+						c.origin, strconv.Itoa(i), got, m, c.otherGots(args, i, oneToOne))
 				}
 				continue
 			}
@@ -373,12 +1076,23 @@ func (c *Call) matches(args []interface{}) error {
 			// Got Foo(a, b, c, d) want Foo(matcherA, matcherB, matcherC, matcherD, matcherE)
 			// Got Foo(a, b, c, d, e) want Foo(matcherA, matcherB, matcherC, matcherD)
 			// Got Foo(a, b, c) want Foo(matcherA, matcherB)
-			return fmt.Errorf("Expected call at %s doesn't match the argument at index %s.\nGot: %v\nWant: %v",
-				c.origin, strconv.Itoa(i), args[i:], c.args[i])
+			return fmt.Errorf("Expected call at %s doesn't match the argument at index %s.\nGot: %v\nWant: %v%s",
+				c.origin, strconv.Itoa(i), args[i:], c.args[i], c.otherGots(args, i, c.methodType.NumIn()-1))
+
+		}
+	}
 
+	for _, assert := range c.argAsserts {
+		if err := assert(args); err != nil {
+			return fmt.Errorf("expected call at %s doesn't satisfy the argument assertion.\nGot: %v\nError: %v",
+				c.origin, args, err)
 		}
 	}
 
+	if err := c.checkGoroutine(); err != nil {
+		return err
+	}
+
 	// Check that all prerequisite calls have been satisfied.
 	for _, preReqCall := range c.preReqs {
 		if !preReqCall.satisfied() {
@@ -389,12 +1103,52 @@ func (c *Call) matches(args []interface{}) error {
 
 	// Check that the call is not exhausted.
 	if c.exhausted() {
-		return fmt.Errorf("expected call at %s has already been called the max number of times", c.origin)
+		return fmt.Errorf("expected call at %s has already been called the max number of times%s",
+			c.origin, c.countMismatch(c.numCalls+1))
 	}
 
 	return nil
 }
 
+// Values of Call.goroutineCheck.
+const (
+	goroutineAny = iota
+	goroutineSame
+	goroutineDifferent
+)
+
+// checkGoroutine returns an error if the current goroutine is not one the
+// call may be made from.
+func (c *Call) checkGoroutine() error {
+	if c.goroutineCheck == goroutineAny {
+		return nil
+	}
+	id := goroutineID()
+	switch {
+	case c.goroutineCheck == goroutineSame && id != c.setupGoroutine:
+		return fmt.Errorf("expected call at %s was made from goroutine %d, but must be made from goroutine %d, which set it up",
+			c.origin, id, c.setupGoroutine)
+	case c.goroutineCheck == goroutineDifferent && id == c.setupGoroutine:
+		return fmt.Errorf("expected call at %s was made from goroutine %d, which set it up, but must be made from another goroutine",
+			c.origin, id)
+	}
+	return nil
+}
+
+// goroutineID returns the ID of the current goroutine, or 0 if it cannot be
+// determined.
+func goroutineID() uint64 {
+	// The first line of the stack trace is "goroutine N [running]:".
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i >= 0 {
+		buf = buf[:i]
+	}
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+	return id
+}
+
 // dropPrereqs tells the expected Call to not re-check prerequisite calls any
 // longer, and to return its current set.
 func (c *Call) dropPrereqs() (preReqs []*Call) {
@@ -403,11 +1157,109 @@ func (c *Call) dropPrereqs() (preReqs []*Call) {
 	return
 }
 
-func (c *Call) call() []func([]interface{}) []interface{} {
+// call records a match of the call with the given sequence number and args,
+// and returns its actions.
+func (c *Call) call(seq int, args []interface{}) []func([]interface{}) []interface{} {
 	c.numCalls++
+	for _, m := range c.args {
+		if cm, ok := m.(capturer); ok {
+			cm.capture()
+		}
+	}
+	for _, h := range c.histograms {
+		h.add(args)
+	}
+	if c.firstMatch == 0 {
+		c.firstMatch = seq
+	}
+	c.lastMatch = seq
 	return c.actions
 }
 
+// argHistogram counts the values of one argument across the matches of a
+// call.
+type argHistogram struct {
+	index  int
+	counts map[interface{}]int
+	check  func(counts map[interface{}]int) error
+}
+
+func (h *argHistogram) add(args []interface{}) {
+	if h.index >= len(args) {
+		return
+	}
+	key := args[h.index]
+	if key != nil && !reflect.TypeOf(key).Comparable() {
+		key = fmt.Sprintf("%v", key)
+	}
+	h.counts[key]++
+}
+
+// AssertArgHistogram declares an assertion about the distribution of the nth
+// argument across all matches of the call. Controller.Finish calls check with
+// the number of matches for each value the argument had, and fails the test
+// if it returns an error. Values that cannot be map keys, such as slices, are
+// counted by their formatting with %v.
+//
+// Example usage:
+//   mock.EXPECT().Send(gomock.Any()).AnyTimes().AssertArgHistogram(0, func(counts map[interface{}]int) error {
+//       if high, total := counts["high"], counts["high"]+counts["low"]; high*10 < total {
+//           return fmt.Errorf("only %d of %d messages had high priority", high, total)
+//       }
+//       return nil
+//   })
+func (c *Call) AssertArgHistogram(n int, check func(counts map[interface{}]int) error) *Call {
+	c.t.Helper()
+
+	return c.addHistogram("AssertArgHistogram", n, check)
+}
+
+// AssertArgUnique declares that the nth argument must have a different value
+// in each match of the call, such as a request ID that must never be reused.
+// Controller.Finish fails the test naming the values that were repeated.
+// Values are compared as by AssertArgHistogram.
+func (c *Call) AssertArgUnique(n int) *Call {
+	c.t.Helper()
+
+	return c.addHistogram("AssertArgUnique", n, func(counts map[interface{}]int) error {
+		var dups []string
+		for v, count := range counts {
+			if count > 1 {
+				dups = append(dups, fmt.Sprintf("%v (%d times)", v, count))
+			}
+		}
+		if len(dups) == 0 {
+			return nil
+		}
+		sort.Strings(dups)
+		return fmt.Errorf("values must be unique, but got duplicates %s", strings.Join(dups, ", "))
+	})
+}
+
+// addHistogram registers a histogram of the nth argument for Finish to check.
+// caller is the name of the method declaring it, for error messages.
+func (c *Call) addHistogram(caller string, n int, check func(counts map[interface{}]int) error) *Call {
+	c.t.Helper()
+
+	ctrl := c.ctrl
+	if ctrl == nil {
+		c.t.Fatalf("%s called for %T.%v, which was not recorded by a Controller [%s]", caller, c.receiver, c.method, c.origin)
+		return c
+	}
+	if n < 0 || n >= c.methodType.NumIn() {
+		c.setupFailed("%s(%d) called for a method with %d args [%s]",
+			caller, n, c.methodType.NumIn(), c.origin)
+		return c
+	}
+	h := &argHistogram{index: n, counts: make(map[interface{}]int), check: check}
+	c.histograms = append(c.histograms, h)
+
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+	ctrl.histograms = append(ctrl.histograms, c)
+	return c
+}
+
 // InOrder declares that the given calls should occur in order.
 func InOrder(calls ...*Call) {
 	for i := 1; i < len(calls); i++ {
@@ -415,6 +1267,139 @@ func InOrder(calls ...*Call) {
 	}
 }
 
+// AllOrNothing declares that either all of the given calls, which must have
+// been recorded by the same Controller, are satisfied, or none of them is
+// matched at all. Controller.Finish fails the test if only some of them are
+// satisfied, and does not report the calls as missing if none is matched.
+// It panics if no calls are given.
+func AllOrNothing(calls ...*Call) {
+	if len(calls) == 0 {
+		panic("gomock: AllOrNothing called without any calls")
+	}
+	ctrl := calls[0].ctrl
+	for _, c := range calls {
+		if c.ctrl == nil {
+			c.t.Helper()
+			c.t.Fatalf("AllOrNothing called for %T.%v, which was not recorded by a Controller [%s]", c.receiver, c.method, c.origin)
+			return
+		}
+		if c.ctrl != ctrl {
+			c.t.Helper()
+			c.t.Fatalf("AllOrNothing called with calls of different controllers: %v and %v", calls[0], c)
+			return
+		}
+	}
+
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+	ctrl.allOrNothing = append(ctrl.allOrNothing, append([]*Call(nil), calls...))
+}
+
+// RequireArgWritten declares that the call's actions, such as a Do callback
+// or SetArg, must write to the nth argument, which must be a pointer or a
+// slice. Each time the call is matched, the pointee, or the slice's elements,
+// are copied before the actions run, and the test fails if they are equal to
+// the copy afterwards. It therefore cannot detect a write of the value that
+// was already there, nor writes beyond a shallow copy, such as into the
+// elements of a slice held by the pointee. The nth argument must not be the
+// variadic one. A pointee that is a map, slice,
+// channel or func is compared by identity, so replacing it counts as a write
+// even with equal contents. The test also fails if the argument is nil or an
+// empty slice, since nothing could be written to it.
+func (c *Call) RequireArgWritten(n int) *Call {
+	c.t.Helper()
+
+	mt := c.methodType
+	if n < 0 || n >= mt.NumIn() {
+		c.setupFailed("RequireArgWritten(%d) called for a method with %d args [%s]",
+			n, mt.NumIn(), c.origin)
+		return c
+	}
+	// The variadic arguments are passed to the actions one by one, so the
+	// slice holding them cannot be written.
+	if mt.IsVariadic() && n == mt.NumIn()-1 {
+		c.setupFailed("RequireArgWritten(%d) referring to the variadic argument of %T.%v [%s]",
+			n, c.receiver, c.method, c.origin)
+		return c
+	}
+	// In the interface case, the argument is checked when the call is made.
+	switch at := mt.In(n); at.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Interface:
+	default:
+		c.setupFailed("RequireArgWritten(%d) referring to argument of non-pointer non-interface non-slice type %v [%s]",
+			n, at, c.origin)
+		return c
+	}
+	c.requireWritten = append(c.requireWritten, n)
+	return c
+}
+
+// snapshotWrittenArgs returns copies of the values that RequireArgWritten
+// requires to be written, indexed like c.requireWritten. Invalid values stand
+// for arguments that are not non-nil pointers or non-empty slices.
+func (c *Call) snapshotWrittenArgs(args []interface{}) []reflect.Value {
+	if len(c.requireWritten) == 0 {
+		return nil
+	}
+	snaps := make([]reflect.Value, len(c.requireWritten))
+	for i, n := range c.requireWritten {
+		if n >= len(args) {
+			continue
+		}
+		v := reflect.ValueOf(args[n])
+		switch v.Kind() {
+		case reflect.Ptr:
+			if !v.IsNil() {
+				snaps[i] = reflect.New(v.Type().Elem()).Elem()
+				snaps[i].Set(v.Elem())
+			}
+		case reflect.Slice:
+			if v.Len() > 0 {
+				snaps[i] = reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+				reflect.Copy(snaps[i], v)
+			}
+		}
+	}
+	return snaps
+}
+
+// checkArgsWritten returns an error if an argument that RequireArgWritten
+// requires to be written is unchanged from its snapshot.
+func (c *Call) checkArgsWritten(args []interface{}, snaps []reflect.Value) error {
+	for i, n := range c.requireWritten {
+		if !snaps[i].IsValid() {
+			return fmt.Errorf("argument %d of call %v must be written, but %v is not a non-nil pointer or a non-empty slice",
+				n, c, args[n])
+		}
+		v := reflect.ValueOf(args[n])
+		var unchanged bool
+		if v.Kind() == reflect.Ptr {
+			v = v.Elem()
+			unchanged = sameReference(v, snaps[i])
+		} else {
+			unchanged = reflect.DeepEqual(v.Interface(), snaps[i].Interface())
+		}
+		if unchanged {
+			return fmt.Errorf("argument %d of call %v was not written by its actions; it is still %v",
+				n, c, v.Interface())
+		}
+	}
+	return nil
+}
+
+// sameReference reports whether a and b, of the same type, are equal, comparing
+// maps, slices, channels and funcs by what they refer to rather than by their
+// contents, which a shallow copy shares.
+func sameReference(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Map:
+		return a.IsNil() == b.IsNil() && a.Pointer() == b.Pointer()
+	case reflect.Slice:
+		return a.IsNil() == b.IsNil() && a.Pointer() == b.Pointer() && a.Len() == b.Len() && a.Cap() == b.Cap()
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
 func setSlice(arg interface{}, v reflect.Value) {
 	va := reflect.ValueOf(arg)
 	for i := 0; i < v.Len(); i++ {
@@ -1,7 +1,9 @@
 package gomock
 
 import (
+	"reflect"
 	"testing"
+	"time"
 )
 
 type mockTestReporter struct {
@@ -49,3 +51,47 @@ func TestCall_After(t *testing.T) {
 		}
 	})
 }
+
+func TestCall_AssertArgUnique_WithoutController(t *testing.T) {
+	tr := &mockTestReporter{}
+	c := newCall(tr, nil, "Func", reflect.TypeOf(func(int) {}))
+	c.AssertArgUnique(0)
+
+	if tr.fatalCalls != 1 {
+		t.Errorf("number of fatal calls == %v, want 1", tr.fatalCalls)
+	}
+}
+
+func TestAllOrNothing_WithoutController(t *testing.T) {
+	tr := &mockTestReporter{}
+	ctrl := NewController(tr)
+	recorded := ctrl.RecordCallWithMethodType(nil, "Func", reflect.TypeOf(func(int) {}), 0)
+	unrecorded := newCall(tr, nil, "Func", reflect.TypeOf(func(int) {}), 1)
+	AllOrNothing(recorded, unrecorded)
+
+	if tr.fatalCalls != 1 {
+		t.Errorf("number of fatal calls == %v, want 1", tr.fatalCalls)
+	}
+	if len(ctrl.allOrNothing) != 0 {
+		t.Errorf("AllOrNothing recorded %d groups, want 0", len(ctrl.allOrNothing))
+	}
+}
+
+func TestCall_Within_TimeoutReturnsZeroValues(t *testing.T) {
+	tr := &mockTestReporter{}
+	c := newCall(tr, nil, "Func", reflect.TypeOf(func(string) (int, error) { return 0, nil })).Within(time.Millisecond)
+
+	release := make(chan struct{})
+	defer close(release)
+	rets := c.runAction(func([]interface{}) []interface{} {
+		<-release
+		return []interface{}{1, nil}
+	}, []interface{}{"a"})
+
+	if tr.fatalCalls != 1 {
+		t.Errorf("number of fatal calls == %v, want 1", tr.fatalCalls)
+	}
+	if want := []interface{}{0, nil}; !reflect.DeepEqual(rets, want) {
+		t.Errorf("runAction() = %v, want %v", rets, want)
+	}
+}
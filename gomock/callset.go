@@ -17,6 +17,8 @@ package gomock
 import (
 	"bytes"
 	"fmt"
+	"math/rand"
+	"sort"
 )
 
 // callSet represents a set of expected calls, indexed by receiver and method
@@ -26,6 +28,8 @@ type callSet struct {
 	expected map[callSetKey][]*Call
 	// Calls that have been exhausted.
 	exhausted map[callSetKey][]*Call
+	// If not nil, the expected calls are tried in a random order.
+	shuffle *rand.Rand
 }
 
 // callSetKey is the key in the maps in callSet
@@ -35,7 +39,7 @@ type callSetKey struct {
 }
 
 func newCallSet() *callSet {
-	return &callSet{make(map[callSetKey][]*Call), make(map[callSetKey][]*Call)}
+	return &callSet{expected: make(map[callSetKey][]*Call), exhausted: make(map[callSetKey][]*Call)}
 }
 
 // Add adds a new expected call.
@@ -68,6 +72,13 @@ func (cs callSet) FindMatch(receiver interface{}, method string, args []interfac
 
 	// Search through the expected calls.
 	expected := cs.expected[key]
+	if cs.shuffle != nil {
+		// Shuffle a copy, since Remove relies on the order of expected.
+		expected = append([]*Call(nil), expected...)
+		cs.shuffle.Shuffle(len(expected), func(i, j int) {
+			expected[i], expected[j] = expected[j], expected[i]
+		})
+	}
 	var callsErrors bytes.Buffer
 	for _, call := range expected {
 		err := call.matches(args)
@@ -106,3 +117,29 @@ func (cs callSet) Failures() []*Call {
 	}
 	return failures
 }
+
+// Methods returns the sorted names of the methods of receiver that still have
+// expected calls.
+func (cs callSet) Methods(receiver interface{}) []string {
+	var methods []string
+	for key, calls := range cs.expected {
+		if key.receiver == receiver && len(calls) > 0 {
+			methods = append(methods, key.fname)
+		}
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// Calls returns all expected and exhausted calls of receiver.
+func (cs callSet) Calls(receiver interface{}) []*Call {
+	var calls []*Call
+	for _, m := range []map[callSetKey][]*Call{cs.expected, cs.exhausted} {
+		for key, cc := range m {
+			if key.receiver == receiver {
+				calls = append(calls, cc...)
+			}
+		}
+	}
+	return calls
+}
@@ -58,9 +58,14 @@ package gomock
 import (
 	"context"
 	"fmt"
+	"log"
+	"math/rand"
 	"reflect"
 	"runtime"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 )
 
 // A TestReporter is something that can be used to report test failures.  It
@@ -77,6 +82,21 @@ type TestHelper interface {
 	Helper()
 }
 
+// ControllerInterface is the part of a Controller that generated mocks depend
+// on. It is implemented by *Controller. Custom implementations, for example
+// ones that wrap a *Controller to log every call, can be passed to the
+// constructors of generated mocks instead of a *Controller.
+type ControllerInterface interface {
+	// TestHelper returns the TestHelper that failures are reported to.
+	TestHelper() TestHelper
+	// RecordCallWithMethodType is called by a mock to set up an expected call.
+	RecordCallWithMethodType(receiver interface{}, method string, methodType reflect.Type, args ...interface{}) *Call
+	// Call is called by a mock when one of its methods is invoked.
+	Call(receiver interface{}, method string, args ...interface{}) []interface{}
+}
+
+var _ ControllerInterface = (*Controller)(nil)
+
 // A Controller represents the top-level control of a mock ecosystem.  It
 // defines the scope and lifetime of mock objects, as well as their
 // expectations.  It is safe to call Controller's methods from multiple
@@ -109,22 +129,191 @@ type Controller struct {
 	// with a nopTestHelper.
 	T             TestHelper
 	mu            sync.Mutex
+	matched       *sync.Cond // broadcast whenever a call is matched
 	expectedCalls *callSet
 	finished      bool
+	mockNames     map[interface{}]string
+	lastOrdered   map[interface{}]*Call // last expected call of each mock given to OrderExpectations
+	callHook      func(method string, args []interface{})
+	logLifecycle  func(event, method string)
+	lazy          []func()          // pending LazyExpect functions
+	callCounts    map[string]int    // number of matched calls by method name
+	totalCalls    int               // number of matched calls of all methods
+	maxTotalCalls int               // 0 means no limit
+	orderAsserts  [][2]*Call        // pairs of calls checked by Finish to match in order
+	allOrNothing  [][]*Call         // groups of calls checked by Finish to be satisfied together
+	histograms    []*Call           // calls with argument histograms checked by Finish
+	ctx           context.Context   // set by WithContext; may be nil
+	labels        map[uint64]string // test names by goroutine ID; nil unless WithGoroutineLabels
+	dryRun        bool              // set by WithDryRun
+	problems      []string          // setup problems recorded in dry-run mode
+
+	// timeCallback, if not nil, is passed the method and the duration of each
+	// run of a Do or DoAndReturn callback. It is called without mu held.
+	timeCallback func(method string, d time.Duration)
+	doTime       time.Duration // total duration of callbacks, for WithDoTimeBudget
+	doTimeBudget time.Duration
 }
 
 // NewController returns a new Controller. It is the preferred way to create a
 // Controller.
-func NewController(t TestReporter) *Controller {
+func NewController(t TestReporter, opts ...ControllerOption) *Controller {
 	h, ok := t.(TestHelper)
 	if !ok {
 		h = nopTestHelper{t}
 	}
 
-	return &Controller{
+	ctrl := &Controller{
 		T:             h,
 		expectedCalls: newCallSet(),
 	}
+	ctrl.matched = sync.NewCond(&ctrl.mu)
+	for _, opt := range opts {
+		opt.apply(ctrl)
+	}
+	return ctrl
+}
+
+// ControllerOption configures how a Controller should behave.
+type ControllerOption interface {
+	apply(*Controller)
+}
+
+type callHookOption func(method string, args []interface{})
+
+func (o callHookOption) apply(ctrl *Controller) {
+	ctrl.callHook = o
+}
+
+// WithCallHook returns a ControllerOption that invokes hook after every call
+// that matches an expectation, with the name of the called method and the
+// arguments it received. The hook runs before the call's actions and without
+// the Controller's lock held, so it may safely call methods on the Controller.
+// Hooks for calls made from multiple goroutines may run concurrently.
+func WithCallHook(hook func(method string, args []interface{})) ControllerOption {
+	return callHookOption(hook)
+}
+
+type maxTotalCallsOption int
+
+func (o maxTotalCallsOption) apply(ctrl *Controller) {
+	ctrl.maxTotalCalls = int(o)
+}
+
+// WithMaxTotalCalls returns a ControllerOption that fails the test fatally
+// once more than n calls, counted across all expected calls of all mocks,
+// have matched. This is a safety net against runaway loops, e.g. in fuzz
+// tests, whose calls each match an AnyTimes expectation.
+func WithMaxTotalCalls(n int) ControllerOption {
+	return maxTotalCallsOption(n)
+}
+
+type shuffleMatchingOption int64
+
+func (o shuffleMatchingOption) apply(ctrl *Controller) {
+	ctrl.expectedCalls.shuffle = rand.New(rand.NewSource(int64(o)))
+}
+
+// WithShuffleMatching returns a ControllerOption that makes a call try the
+// expected calls that may match it in a random order, drawn from seed, rather
+// than in the order they were recorded. It is a debugging aid to surface
+// tests that accidentally depend on which of several matching expectations is
+// consumed first. Constraints declared with After or InOrder still hold, since
+// an expected call whose prerequisites are not satisfied never matches.
+func WithShuffleMatching(seed int64) ControllerOption {
+	return shuffleMatchingOption(seed)
+}
+
+type dryRunOption struct{}
+
+func (dryRunOption) apply(ctrl *Controller) {
+	ctrl.dryRun = true
+}
+
+// WithDryRun returns a ControllerOption that collects the problems found while
+// expected calls are set up, instead of failing the test as soon as each is
+// found, so that Validate can report all of them at once. These are problems
+// with the arguments of Return, ReturnSequence, ReturnPtr, FailNThenSucceed,
+// TimesRange, SetArg, RequireArgWritten, AssertArgHistogram and
+// AssertArgUnique, with the functions given to Do, DoAndReturn and
+// DoWithContext, and loops in the order of calls declared with After or
+// InOrder. The setup step that caused a problem has no effect. Problems that
+// Validate has not returned are reported by Finish.
+func WithDryRun() ControllerOption {
+	return dryRunOption{}
+}
+
+type goroutineLabelsOption struct{}
+
+func (goroutineLabelsOption) apply(ctrl *Controller) {
+	ctrl.labels = make(map[uint64]string)
+}
+
+// WithGoroutineLabels returns a ControllerOption that attributes expected and
+// unexpected calls to the goroutines, and the tests, they come from. This
+// helps to tell which subtest caused a failure when parallel subtests share
+// mocks. Each expected call records the goroutine that set it up, and failure
+// messages about it name that goroutine; an unexpected call names the
+// goroutine that made it. The test of a goroutine is the name given to
+// LabelGoroutine on it, or else the name of the Controller's TestReporter.
+func WithGoroutineLabels() ControllerOption {
+	return goroutineLabelsOption{}
+}
+
+// LabelGoroutine names the test running on the calling goroutine, such as a
+// parallel subtest, in failure messages of a Controller created with
+// WithGoroutineLabels. It has no effect otherwise. The labels belong to the
+// Controller and are discarded by Finish.
+//
+//   t.Run("sub", func(t *testing.T) {
+//     t.Parallel()
+//     ctrl.LabelGoroutine(t.Name())
+//     // ..
+//   })
+func (ctrl *Controller) LabelGoroutine(name string) {
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+
+	if ctrl.labels != nil {
+		ctrl.labels[goroutineID()] = name
+	}
+}
+
+// goroutineLabel describes the calling goroutine for failure messages, or
+// returns "" unless WithGoroutineLabels was given. It must be called with
+// ctrl.mu held.
+func (ctrl *Controller) goroutineLabel() string {
+	if ctrl.labels == nil {
+		return ""
+	}
+	id := goroutineID()
+	name, ok := ctrl.labels[id]
+	if !ok {
+		name = testName(ctrl.T)
+	}
+	if name == "" {
+		return fmt.Sprintf("goroutine %d", id)
+	}
+	return fmt.Sprintf("goroutine %d of %s", id, name)
+}
+
+type doTimeBudgetOption time.Duration
+
+func (o doTimeBudgetOption) apply(ctrl *Controller) {
+	ctrl.doTimeBudget = time.Duration(o)
+	ctrl.timeCallback = func(method string, d time.Duration) {
+		ctrl.mu.Lock()
+		defer ctrl.mu.Unlock()
+		ctrl.doTime += d
+	}
+}
+
+// WithDoTimeBudget returns a ControllerOption that makes Finish fail the test
+// if the functions given to Do and DoAndReturn, across all expected calls,
+// took longer than d to run in total. It is meant for tests that guard the
+// performance of code whose dependencies are simulated by such functions.
+func WithDoTimeBudget(d time.Duration) ControllerOption {
+	return doTimeBudgetOption(d)
 }
 
 type cancelReporter struct {
@@ -149,7 +338,15 @@ func WithContext(ctx context.Context, t TestReporter) (*Controller, context.Cont
 	}
 
 	ctx, cancel := context.WithCancel(ctx)
-	return NewController(&cancelReporter{h, cancel}), ctx
+	ctrl := NewController(&cancelReporter{h, cancel})
+	ctrl.ctx = ctx
+	return ctrl, ctx
+}
+
+// TestHelper returns ctrl.T. It is called by a mock. It should not be called
+// by user code.
+func (ctrl *Controller) TestHelper() TestHelper {
+	return ctrl.T
 }
 
 type nopTestHelper struct {
@@ -158,6 +355,41 @@ type nopTestHelper struct {
 
 func (h nopTestHelper) Helper() {}
 
+// Events of the lifecycle of an expected call, as passed to the logger of
+// WithLifecycleLogger.
+const (
+	// EventCreated is logged when an expected call is recorded.
+	EventCreated = "created"
+	// EventMatched is logged each time an expected call is matched.
+	EventMatched = "matched"
+	// EventExhausted is logged when an expected call has been matched its
+	// maximum number of times.
+	EventExhausted = "exhausted"
+	// EventUnmet is logged by Finish for each expected call that was not
+	// matched its minimum number of times.
+	EventUnmet = "unmet"
+)
+
+type lifecycleLoggerOption func(event, method string)
+
+func (o lifecycleLoggerOption) apply(ctrl *Controller) {
+	ctrl.logLifecycle = o
+}
+
+// WithLifecycleLogger returns a ControllerOption that invokes logger with one
+// of the Event constants and the method name at each transition in the
+// lifecycle of an expected call. The logger is invoked with the Controller's
+// lock held, so it must not call methods on the Controller.
+func WithLifecycleLogger(logger func(event, method string)) ControllerOption {
+	return lifecycleLoggerOption(logger)
+}
+
+func (ctrl *Controller) logEvent(event string, call *Call) {
+	if ctrl.logLifecycle != nil {
+		ctrl.logLifecycle(event, call.method)
+	}
+}
+
 // RecordCall is called by a mock. It should not be called by user code.
 func (ctrl *Controller) RecordCall(receiver interface{}, method string, args ...interface{}) *Call {
 	ctrl.T.Helper()
@@ -180,7 +412,19 @@ func (ctrl *Controller) RecordCallWithMethodType(receiver interface{}, method st
 
 	ctrl.mu.Lock()
 	defer ctrl.mu.Unlock()
+	call.name = ctrl.mockNames[receiver]
+	call.ctrl = ctrl
+	if prev, ok := ctrl.lastOrdered[receiver]; ok {
+		if prev != nil {
+			call.After(prev)
+		}
+		ctrl.lastOrdered[receiver] = call
+	}
+	if label := ctrl.goroutineLabel(); label != "" {
+		call.origin += ", recorded on " + label
+	}
 	ctrl.expectedCalls.Add(call)
+	ctrl.logEvent(EventCreated, call)
 
 	return call
 }
@@ -190,15 +434,22 @@ func (ctrl *Controller) Call(receiver interface{}, method string, args ...interf
 	ctrl.T.Helper()
 
 	// Nest this code so we can use defer to make sure the lock is released.
-	actions := func() []func([]interface{}) []interface{} {
+	expected, actions, onSatisfied := func() (*Call, []func([]interface{}) []interface{}, []func()) {
 		ctrl.T.Helper()
 		ctrl.mu.Lock()
 		defer ctrl.mu.Unlock()
 
 		expected, err := ctrl.expectedCalls.FindMatch(receiver, method, args)
+		if err != nil && len(ctrl.lazy) > 0 {
+			ctrl.runLazyExpectations()
+			expected, err = ctrl.expectedCalls.FindMatch(receiver, method, args)
+		}
 		if err != nil {
 			origin := callerInfo(2)
-			ctrl.T.Fatalf("Unexpected call to %T.%v(%v) at %s because: %s", receiver, method, args, origin, err)
+			if label := ctrl.goroutineLabel(); label != "" {
+				origin += " on " + label
+			}
+			ctrl.T.Fatalf("Unexpected call to %s.%v(%v) at %s because: %s", ctrl.mockName(receiver), method, args, origin, err)
 		}
 
 		// Two things happen here:
@@ -209,23 +460,307 @@ func (ctrl *Controller) Call(receiver interface{}, method string, args ...interf
 			ctrl.expectedCalls.Remove(preReqCall)
 		}
 
-		actions := expected.call()
+		ctrl.totalCalls++
+		actions := expected.call(ctrl.totalCalls, args)
+		if ctrl.callCounts == nil {
+			ctrl.callCounts = make(map[string]int)
+		}
+		ctrl.callCounts[method]++
+		if ctrl.maxTotalCalls > 0 && ctrl.totalCalls > ctrl.maxTotalCalls {
+			origin := callerInfo(2)
+			ctrl.T.Fatalf("Too many calls: call %d to %s.%v(%v) at %s exceeds the limit of %d matched calls",
+				ctrl.totalCalls, ctrl.mockName(receiver), method, args, origin, ctrl.maxTotalCalls)
+		}
+		expected.warnIfLate()
+		if err := expected.checkInterval(time.Now()); err != nil {
+			ctrl.T.Errorf("%v", err)
+		}
+		ctrl.logEvent(EventMatched, expected)
 		if expected.exhausted() {
 			ctrl.expectedCalls.Remove(expected)
+			ctrl.logEvent(EventExhausted, expected)
 		}
-		return actions
+		ctrl.matched.Broadcast()
+		return expected, actions, expected.justSatisfied()
 	}()
 
+	if ctrl.callHook != nil {
+		ctrl.callHook(method, args)
+	}
+
+	written := expected.snapshotWrittenArgs(args)
 	var rets []interface{}
 	for _, action := range actions {
-		if r := action(args); r != nil {
+		if r := expected.runAction(action, args); r != nil {
 			rets = r
 		}
 	}
+	if written != nil {
+		if err := expected.checkArgsWritten(args, written); err != nil {
+			ctrl.T.Errorf("%v", err)
+		}
+	}
+	for _, f := range onSatisfied {
+		f()
+	}
 
 	return rets
 }
 
+// LazyExpect registers f to set up expectations on demand. The first time a
+// call matches no expected call, every function registered so far is run, in
+// the order it was registered, and the call is then matched again before it
+// is reported as unexpected. Each function runs at most once; functions
+// registered afterwards wait for the next unmatched call.
+func (ctrl *Controller) LazyExpect(f func()) {
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+
+	ctrl.lazy = append(ctrl.lazy, f)
+}
+
+// runLazyExpectations runs and clears the pending LazyExpect functions. It
+// must be called with ctrl.mu held, which it releases while the functions run
+// so that they can record expected calls.
+func (ctrl *Controller) runLazyExpectations() {
+	lazy := ctrl.lazy
+	ctrl.lazy = nil
+
+	ctrl.mu.Unlock()
+	defer ctrl.mu.Lock()
+	for _, f := range lazy {
+		f()
+	}
+}
+
+// AssertOrder declares that every match of the expected call a must precede
+// every match of the expected call b, which Finish then checks. Unlike
+// InOrder, it does not affect which calls match, so it can relate calls of
+// different mocks without constraining any other calls. The check passes if
+// either call was never matched.
+func (ctrl *Controller) AssertOrder(a, b *Call) {
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+
+	ctrl.orderAsserts = append(ctrl.orderAsserts, [2]*Call{a, b})
+}
+
+// NameMock gives mock a name, which failure messages use to refer to it
+// instead of its type. This tells apart mocks of the same type.
+func (ctrl *Controller) NameMock(mock interface{}, name string) {
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+
+	if ctrl.mockNames == nil {
+		ctrl.mockNames = make(map[interface{}]string)
+	}
+	ctrl.mockNames[mock] = name
+	for _, call := range ctrl.expectedCalls.Calls(mock) {
+		call.name = name
+	}
+}
+
+// OrderExpectations declares that the calls expected of mock from now on must
+// be made in the order they are recorded, as if each were passed to InOrder
+// along with the one recorded before it. Constraints declared with After and
+// InOrder apply in addition to this order.
+//
+// As with InOrder, an expected call stops matching once a later one has been
+// matched, even if it may be called any number of times. So after
+//
+//   ctrl.OrderExpectations(mock)
+//   mock.EXPECT().Open()
+//   mock.EXPECT().Read().AnyTimes()
+//   mock.EXPECT().Close()
+//
+// Read may be called any number of times between Open and Close, including
+// none, but not after Close.
+func (ctrl *Controller) OrderExpectations(mock interface{}) {
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+
+	if ctrl.lastOrdered == nil {
+		ctrl.lastOrdered = make(map[interface{}]*Call)
+	}
+	if _, ok := ctrl.lastOrdered[mock]; !ok {
+		ctrl.lastOrdered[mock] = nil
+	}
+}
+
+// mockName returns the name given to mock by NameMock, or else its type.
+func (ctrl *Controller) mockName(mock interface{}) string {
+	if name, ok := ctrl.mockNames[mock]; ok {
+		return name
+	}
+	return fmt.Sprintf("%T", mock)
+}
+
+// ExpectedMethods returns the sorted, distinct names of the methods of mock
+// that currently have expected calls which are not yet exhausted.
+func (ctrl *Controller) ExpectedMethods(mock interface{}) []string {
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+
+	return ctrl.expectedCalls.Methods(mock)
+}
+
+// ExpectationInfo describes an expected call, as returned by
+// PendingExpectations.
+type ExpectationInfo struct {
+	Receiver string   // type of the mock, such as "*mock_foo.MockBar"
+	Name     string   // name given to the mock by NameMock, if any
+	Method   string   // name of the method
+	Args     []string // descriptions of the argument matchers
+	MinCalls int      // minimum number of calls expected
+	MaxCalls int      // maximum number of calls expected, or -1 if unlimited
+	NumCalls int      // number of calls matched so far
+	Origin   string   // file and line number where the call was recorded
+}
+
+// PendingExpectations returns the expected calls that have not yet been made
+// their minimum number of times, which Finish would report as missing. Calls
+// of an AllOrNothing group none of whose calls was matched are left out, since
+// Finish does not require them. They are sorted by receiver type, method and origin. The returned values are
+// copies, which do not change as further calls are made.
+func (ctrl *Controller) PendingExpectations() []ExpectationInfo {
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+
+	failures := ctrl.missingCalls()
+	pending := make([]ExpectationInfo, len(failures))
+	for i, call := range failures {
+		args := make([]string, len(call.args))
+		for j, arg := range call.args {
+			args[j] = arg.String()
+		}
+		maxCalls := call.maxCalls
+		if maxCalls >= 1e8 {
+			maxCalls = -1
+		}
+		pending[i] = ExpectationInfo{
+			Receiver: fmt.Sprintf("%T", call.receiver),
+			Name:     call.name,
+			Method:   call.method,
+			Args:     args,
+			MinCalls: call.minCalls,
+			MaxCalls: maxCalls,
+			NumCalls: call.numCalls,
+			Origin:   call.origin,
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		a, b := pending[i], pending[j]
+		if a.Receiver != b.Receiver {
+			return a.Receiver < b.Receiver
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		return a.Origin < b.Origin
+	})
+	return pending
+}
+
+// CountSnapshot returns the number of matched calls made so far to each
+// method, keyed by method name. Calls to methods of the same name on different
+// mocks are counted together. Methods that have not been called are absent.
+// Use CountDelta to compare snapshots taken at different points of a test.
+func (ctrl *Controller) CountSnapshot() map[string]int {
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+
+	counts := make(map[string]int, len(ctrl.callCounts))
+	for method, n := range ctrl.callCounts {
+		counts[method] = n
+	}
+	return counts
+}
+
+// CountDelta returns the number of calls made to each method between the
+// snapshots before and after taken by CountSnapshot. Methods that were not
+// called in between are absent.
+//
+// Example usage:
+//   before := ctrl.CountSnapshot()
+//   runPhase()
+//   delta := gomock.CountDelta(before, ctrl.CountSnapshot()) // e.g. map[Get:2]
+func CountDelta(before, after map[string]int) map[string]int {
+	delta := make(map[string]int)
+	for method, n := range after {
+		if d := n - before[method]; d != 0 {
+			delta[method] = d
+		}
+	}
+	return delta
+}
+
+// Satisfied reports whether all expected calls have been made at least their
+// minimum number of times, except those of AllOrNothing groups none of whose
+// calls was matched, just as Finish requires. Unlike Finish, it neither
+// reports failures nor changes the state of the Controller, so it may be
+// polled, for example while waiting for calls made from other goroutines.
+// Finish must still be called.
+func (ctrl *Controller) Satisfied() bool {
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+
+	return len(ctrl.missingCalls()) == 0
+}
+
+// Validate returns an error listing the problems found so far while setting up
+// the expected calls of a Controller created with WithDryRun, or nil if there
+// are none. It lets a test check that its expectations are consistent before
+// running the code under test. Without WithDryRun, such problems fail the test
+// right away, so Validate always returns nil.
+func (ctrl *Controller) Validate() error {
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+
+	if len(ctrl.problems) == 0 {
+		return nil
+	}
+	err := fmt.Errorf("%d problem(s) with expected calls:\n%s",
+		len(ctrl.problems), strings.Join(ctrl.problems, "\n"))
+	ctrl.problems = nil
+	return err
+}
+
+// WaitForExpectations blocks until all expected calls have been made at least
+// their minimum number of times, except those of AllOrNothing groups none of
+// whose calls was matched, as Finish requires, or until timeout elapses. It returns true
+// immediately if the expectations are already satisfied. Otherwise, if the
+// timeout elapses first, each expected call that is still missing is reported
+// as an error and false is returned. It is intended for tests in which mocks
+// are called asynchronously; Finish must still be called afterwards.
+func (ctrl *Controller) WaitForExpectations(timeout time.Duration) bool {
+	ctrl.T.Helper()
+
+	deadline := time.Now().Add(timeout)
+	timer := time.AfterFunc(timeout, func() {
+		ctrl.mu.Lock()
+		defer ctrl.mu.Unlock()
+		ctrl.matched.Broadcast()
+	})
+	defer timer.Stop()
+
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+
+	for {
+		failures := ctrl.missingCalls()
+		if len(failures) == 0 {
+			return true
+		}
+		if !time.Now().Before(deadline) {
+			for _, call := range failures {
+				ctrl.T.Errorf("missing call(s) to %v after waiting %v", call, timeout)
+			}
+			return false
+		}
+		ctrl.matched.Wait()
+	}
+}
+
 // Finish checks to see if all the methods that were expected to be called
 // were called. It should be invoked for each Controller. It is not idempotent
 // and therefore can only be invoked once.
@@ -239,6 +774,9 @@ func (ctrl *Controller) Finish() {
 		ctrl.T.Fatalf("Controller.Finish was called more than once. It has to be called exactly once.")
 	}
 	ctrl.finished = true
+	if ctrl.labels != nil {
+		ctrl.labels = make(map[uint64]string)
+	}
 
 	// If we're currently panicking, probably because this is a deferred call,
 	// pass through the panic.
@@ -246,16 +784,133 @@ func (ctrl *Controller) Finish() {
 		panic(err)
 	}
 
+	for _, problem := range ctrl.problems {
+		ctrl.T.Errorf("%s", problem)
+	}
+
+	for _, pair := range ctrl.orderAsserts {
+		a, b := pair[0], pair[1]
+		if a.lastMatch > 0 && b.firstMatch > 0 && a.lastMatch > b.firstMatch {
+			ctrl.T.Errorf("call %v was matched after call %v, but must precede it", a, b)
+		}
+	}
+
+	if ctrl.timeCallback != nil && ctrl.doTime > ctrl.doTimeBudget {
+		ctrl.T.Errorf("Do and DoAndReturn functions ran for %v in total, exceeding the budget of %v",
+			ctrl.doTime, ctrl.doTimeBudget)
+	}
+
+	for _, call := range ctrl.histograms {
+		for _, h := range call.histograms {
+			if err := h.check(h.counts); err != nil {
+				ctrl.T.Errorf("histogram of argument %d of call %v: %v", h.index, call, err)
+			}
+		}
+	}
+
+	for _, group := range ctrl.allOrNothing {
+		if !anyMatched(group) {
+			continue
+		}
+		satisfied := 0
+		for _, call := range group {
+			if call.satisfied() {
+				satisfied++
+			}
+		}
+		if satisfied < len(group) {
+			ctrl.T.Errorf("only %d of %d calls grouped by AllOrNothing were satisfied, starting with %v",
+				satisfied, len(group), group[0])
+		}
+	}
+
 	// Check that all remaining expected calls are satisfied.
-	failures := ctrl.expectedCalls.Failures()
+	failures := ctrl.missingCalls()
 	for _, call := range failures {
-		ctrl.T.Errorf("missing call(s) to %v", call)
+		ctrl.logEvent(EventUnmet, call)
+		ctrl.T.Errorf("missing call(s) to %v%s", call, call.countMismatch(call.numCalls))
 	}
 	if len(failures) != 0 {
 		ctrl.T.Fatalf("aborting test due to missing call(s)")
 	}
 }
 
+// missingCalls returns the expected calls that have not been made their
+// minimum number of times, except those of AllOrNothing groups none of whose
+// calls was matched, which Finish does not require. ctrl.mu must be held.
+func (ctrl *Controller) missingCalls() []*Call {
+	excused := make(map[*Call]bool)
+	for _, group := range ctrl.allOrNothing {
+		if !anyMatched(group) {
+			for _, call := range group {
+				excused[call] = true
+			}
+		}
+	}
+	var missing []*Call
+	for _, call := range ctrl.expectedCalls.Failures() {
+		if !excused[call] {
+			missing = append(missing, call)
+		}
+	}
+	return missing
+}
+
+// anyMatched reports whether any of calls has been matched.
+func anyMatched(calls []*Call) bool {
+	for _, call := range calls {
+		if call.numCalls > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// logf logs a message with the Logf method of the TestReporter underlying t
+// if it has one, and otherwise with the standard logger, since reporting it
+// with Errorf would fail the test.
+func logf(t TestHelper, format string, args ...interface{}) {
+	t.Helper()
+
+	var r TestReporter = t
+	for {
+		switch w := r.(type) {
+		case nopTestHelper:
+			r = w.TestReporter
+			continue
+		case *cancelReporter:
+			r = w.TestHelper
+			continue
+		case interface {
+			Logf(format string, args ...interface{})
+		}:
+			w.Logf(format, args...)
+			return
+		}
+		log.Printf("gomock: "+format, args...)
+		return
+	}
+}
+
+// testName returns the name of the test underlying t if it has a Name method,
+// as *testing.T does, and "" otherwise.
+func testName(t TestHelper) string {
+	var r TestReporter = t
+	for {
+		switch w := r.(type) {
+		case nopTestHelper:
+			r = w.TestReporter
+			continue
+		case *cancelReporter:
+			r = w.TestHelper
+			continue
+		case interface{ Name() string }:
+			return w.Name()
+		}
+		return ""
+	}
+}
+
 func callerInfo(skip int) string {
 	if _, file, line, ok := runtime.Caller(skip + 1); ok {
 		return fmt.Sprintf("%s:%d", file, line)
@@ -15,9 +15,16 @@
 package gomock_test
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	"strings"
 
@@ -156,6 +163,28 @@ func (s *Subject) ActOnTestStructMethod(arg TestStruct, arg1 int) int {
 
 func (s *Subject) SetArgMethod(sliceArg []byte, ptrArg *int) {}
 
+func (s *Subject) FetchMethod(arg string) (int, error) {
+	return 0, nil
+}
+
+func (s *Subject) CopyMethod(dst, src string) {}
+
+func (s *Subject) LoadMethod(ctx context.Context, key string) (string, error) {
+	return "", nil
+}
+
+func (s *Subject) SubscribeMethod(ch chan<- string) {}
+
+func (s *Subject) ReceiveMethod(ch <-chan string) {}
+
+func (s *Subject) PipeMethod(ch chan string) chan string {
+	return ch
+}
+
+func (s *Subject) ScheduleMethod(delay time.Duration, at time.Time) {}
+
+func (s *Subject) DecodeMethod(dst interface{}) {}
+
 func assertEqual(t *testing.T, expected interface{}, actual interface{}) {
 	if !reflect.DeepEqual(expected, actual) {
 		t.Errorf("Expected %+v, but got %+v", expected, actual)
@@ -374,6 +403,66 @@ func TestUnexpectedArgValue_GotFormatter(t *testing.T) {
 	})
 }
 
+func TestUnexpectedArgValue_TimeFormatting(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	subject := new(Subject)
+
+	at := time.Date(2020, time.March, 1, 12, 0, 0, 0, time.UTC)
+	ctrl.RecordCall(subject, "ScheduleMethod", 1500*time.Millisecond, at)
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "ScheduleMethod", 2*time.Second, at)
+	}, "doesn't match the argument at index 0",
+		"Got: 2s\nWant: is equal to 1.5s")
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "ScheduleMethod", 1500*time.Millisecond, at.Add(time.Hour))
+	}, "doesn't match the argument at index 1",
+		"Got: 2020-03-01 13:00:00 +0000 UTC\nWant: is equal to 2020-03-01 12:00:00 +0000 UTC")
+
+	reporter.assertFatal(func() {
+		// The expected call wasn't made.
+		ctrl.Finish()
+	})
+}
+
+func TestUnexpectedArgValue_AnyShowsGot(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", gomock.Any(), 15)
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 123, Message: "hello"}, 3)
+	}, "doesn't match the argument at index 1",
+		"Got: 3\nWant: is equal to 15\nGot at index 0: {123 hello} (gomock_test.TestStruct)")
+
+	reporter.assertFatal(func() {
+		// The expected call wasn't made.
+		ctrl.Finish()
+	})
+}
+
+func TestUnexpectedArgValue_AnyShowsGot_Variadic(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "VariadicMethod", 1, gomock.Any())
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "VariadicMethod", 2, "a")
+	}, "doesn't match the argument at index 0",
+		"Got: 2\nWant: is equal to 1\nGot at index 1: a (string)")
+
+	ctrl.RecordCall(subject, "VariadicMethod", gomock.Any(), "b")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "VariadicMethod", 3, "c")
+	}, "doesn't match the argument at index 1",
+		"Want: is equal to b\nGot at index 0: 3 (int)")
+}
+
 func TestAnyTimes(t *testing.T) {
 	reporter, ctrl := createFixtures(t)
 	subject := new(Subject)
@@ -386,6 +475,19 @@ func TestAnyTimes(t *testing.T) {
 	ctrl.Finish()
 }
 
+func TestOptional(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "argument").Optional()
+	ctrl.RecordCall(subject, "BarMethod", "argument").Optional()
+	for i := 0; i < 3; i++ {
+		ctrl.Call(subject, "BarMethod", "argument")
+	}
+	ctrl.Finish()
+	reporter.assertPass("unmatched and repeatedly matched optional calls")
+}
+
 func TestMinTimes1(t *testing.T) {
 	// It fails if there are no calls
 	reporter, ctrl := createFixtures(t)
@@ -548,6 +650,204 @@ func TestDoAndReturn(t *testing.T) {
 	ctrl.Finish()
 }
 
+type codedError struct{ code int }
+
+func (e *codedError) Error() string { return fmt.Sprintf("code %d", e.code) }
+
+func TestDoAndReturn_ValidSignatures(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	var varargs []string
+	ctrl.RecordCall(subject, "VariadicMethod", 1, "a", "b").DoAndReturn(func(arg int, vararg ...string) {
+		varargs = vararg
+	})
+	ctrl.RecordCall(subject, "FetchMethod", "key").DoAndReturn(func(key interface{}) (int, *codedError) {
+		return 0, &codedError{404}
+	})
+	copied := false
+	ctrl.RecordCall(subject, "CopyMethod", "dst", "src").DoAndReturn(func(dst, src string) {
+		copied = true
+	})
+
+	ctrl.Call(subject, "VariadicMethod", 1, "a", "b")
+	if want := []string{"a", "b"}; !reflect.DeepEqual(varargs, want) {
+		t.Errorf("variadic args = %v, want %v", varargs, want)
+	}
+	rets := ctrl.Call(subject, "FetchMethod", "key")
+	if err, ok := rets[1].(error); !ok || err.Error() != "code 404" {
+		t.Errorf("FetchMethod returned %v, want an error with code 404", rets[1])
+	}
+	ctrl.Call(subject, "CopyMethod", "dst", "src")
+	if !copied {
+		t.Error("DoAndReturn function of a method without results not called")
+	}
+	ctrl.Finish()
+	reporter.assertPass("valid DoAndReturn functions")
+}
+
+func TestDoAndReturn_InvalidSignatures(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		method string
+		f      interface{}
+		want   string
+	}{
+		{"not a function", "FooMethod", 5, "argument to DoAndReturn for *gomock_test.Subject.FooMethod is int, not a function"},
+		{"too few results", "FetchMethod", func(string) int { return 0 }, "wrong number of return values of function passed to DoAndReturn for *gomock_test.Subject.FetchMethod: got 1, want 2"},
+		{"results for a method without any", "CopyMethod", func(dst, src string) error { return nil }, "got 1, want 0"},
+		{"wrong result type", "FooMethod", func(string) int64 { return 0 }, "wrong type of return value 0 of function passed to DoAndReturn for *gomock_test.Subject.FooMethod: int64 is not assignable to int"},
+		{"result of an unimplemented interface", "FetchMethod", func(string) (int, fmt.Stringer) { return 0, nil }, "fmt.Stringer is not assignable to error"},
+		{"too many arguments", "FooMethod", func(string, int) int { return 0 }, "does not take the arguments of func(string) int"},
+		{"wrong argument type", "FooMethod", func(int) int { return 0 }, "wrong type of argument 0 of function passed to DoAndReturn for *gomock_test.Subject.FooMethod: string is not assignable to int"},
+		{"variadic method", "VariadicMethod", func(int, []string) {}, "does not take the arguments of func(int, ...string)"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter, ctrl := createFixtures(t)
+			subject := new(Subject)
+
+			reporter.assertFatal(func() {
+				ctrl.RecordCall(subject, tt.method, gomock.Any(), gomock.Any()).DoAndReturn(tt.f)
+			}, tt.want)
+		})
+	}
+}
+
+type namedReporter struct {
+	*ErrorReporter
+	name string
+}
+
+func (r namedReporter) Name() string { return r.name }
+
+func TestWithGoroutineLabels(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(namedReporter{reporter, "TestParent"}, gomock.WithGoroutineLabels())
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ctrl.LabelGoroutine("TestParent/sub")
+		ctrl.RecordCall(subject, "BarMethod", "2")
+		reporter.assertFatal(func() {
+			ctrl.Call(subject, "FooMethod", "3")
+		}, "Unexpected call to", "of TestParent/sub because")
+	}()
+	<-done
+
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	}, "aborting test due to missing call(s)")
+	missing := strings.Join(reporter.log, "\n")
+	for _, want := range []string{
+		"missing call(s) to *gomock_test.Subject.FooMethod(is equal to 1)",
+		"missing call(s) to *gomock_test.Subject.BarMethod(is equal to 2)",
+		" of TestParent\n",
+		" of TestParent/sub\n",
+	} {
+		if !strings.Contains(missing, want) {
+			t.Errorf("failures %q do not contain %q", missing, want)
+		}
+	}
+}
+
+func TestWithoutGoroutineLabels(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(namedReporter{reporter, "TestParent"})
+	subject := new(Subject)
+
+	ctrl.LabelGoroutine("ignored")
+	ctrl.RecordCall(subject, "FooMethod", "1")
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	}, "aborting test due to missing call(s)")
+	for _, msg := range reporter.log {
+		if strings.Contains(msg, "goroutine") {
+			t.Errorf("unexpected goroutine label in %q", msg)
+		}
+	}
+}
+
+func TestNotifyOn(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	matched := make(chan struct{}, 2)
+	ctrl.RecordCall(subject, "FooMethod", "1").Return(7).Times(3).NotifyOn(matched)
+
+	go func() {
+		ctrl.Call(subject, "FooMethod", "1")
+		ctrl.Call(subject, "FooMethod", "1")
+	}()
+	for i := 0; i < 2; i++ {
+		select {
+		case <-matched:
+		case <-time.After(time.Second):
+			t.Fatalf("notification %d not received", i+1)
+		}
+	}
+
+	// With the buffer full, a match does not block but drops the notification.
+	matched <- struct{}{}
+	matched <- struct{}{}
+	if rets := ctrl.Call(subject, "FooMethod", "1"); rets[0] != 7 {
+		t.Errorf("FooMethod returned %v, want 7", rets[0])
+	}
+	ctrl.Finish()
+	reporter.assertPass("notified calls")
+}
+
+func TestReturnSequence(t *testing.T) {
+	t.Run("RepeatsLast", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "FooMethod", "1").ReturnSequence([]interface{}{1}, []interface{}{2}, []interface{}{3}).Times(4)
+		var got []interface{}
+		for i := 0; i < 4; i++ {
+			got = append(got, ctrl.Call(subject, "FooMethod", "1")[0])
+		}
+		if want := []interface{}{1, 2, 3, 3}; !reflect.DeepEqual(got, want) {
+			t.Errorf("returned %v, want %v", got, want)
+		}
+		ctrl.Finish()
+		reporter.assertPass("calls returning a sequence")
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "FetchMethod", "1").StrictSequence().AnyTimes().
+			ReturnSequence([]interface{}{1, nil}, []interface{}{0, errors.New("done")})
+		ctrl.Call(subject, "FetchMethod", "1")
+		if rets := ctrl.Call(subject, "FetchMethod", "1"); rets[1].(error).Error() != "done" {
+			t.Errorf("second call returned %v, want the error done", rets)
+		}
+		reporter.assertFatal(func() {
+			ctrl.Call(subject, "FetchMethod", "1")
+		}, "exceeded its sequence of 2 return values")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		reporter.assertFatal(func() {
+			ctrl.RecordCall(subject, "FooMethod", "1").ReturnSequence([]interface{}{1}, []interface{}{"two"})
+		}, "wrong type of argument 0 to ReturnSequence")
+		reporter.assertFatal(func() {
+			ctrl.RecordCall(subject, "FooMethod", "1").ReturnSequence([]interface{}{1}, []interface{}{})
+		}, "wrong number of arguments to ReturnSequence")
+		reporter.assertFatal(func() {
+			ctrl.RecordCall(subject, "FooMethod", "1").ReturnSequence()
+		}, "ReturnSequence called for *gomock_test.Subject.FooMethod without any return values")
+	})
+}
+
 func TestSetArgSlice(t *testing.T) {
 	_, ctrl := createFixtures(t)
 	subject := new(Subject)
@@ -600,129 +900,584 @@ func TestReturn(t *testing.T) {
 	ctrl.Finish()
 }
 
-func TestUnorderedCalls(t *testing.T) {
-	reporter, ctrl := createFixtures(t)
-	defer reporter.recoverUnexpectedFatal()
-	subjectTwo := new(Subject)
-	subjectOne := new(Subject)
-
-	ctrl.RecordCall(subjectOne, "FooMethod", "1")
-	ctrl.RecordCall(subjectOne, "BarMethod", "2")
-	ctrl.RecordCall(subjectTwo, "FooMethod", "3")
-	ctrl.RecordCall(subjectTwo, "BarMethod", "4")
-
-	// Make the calls in a different order, which should be fine.
-	ctrl.Call(subjectOne, "BarMethod", "2")
-	ctrl.Call(subjectTwo, "FooMethod", "3")
-	ctrl.Call(subjectTwo, "BarMethod", "4")
-	ctrl.Call(subjectOne, "FooMethod", "1")
+func TestReturnPtr(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
 
-	reporter.assertPass("After making all calls in different order")
+	ret := 1
+	ctrl.RecordCall(subject, "FooMethod", "arg").ReturnPtr(&ret).Times(2)
 
+	assertEqual(t, []interface{}{1}, ctrl.Call(subject, "FooMethod", "arg"))
+	ret = 5
+	assertEqual(t, []interface{}{5}, ctrl.Call(subject, "FooMethod", "arg"))
 	ctrl.Finish()
-
-	reporter.assertPass("After finish")
 }
 
-func commonTestOrderedCalls(t *testing.T) (reporter *ErrorReporter, ctrl *gomock.Controller, subjectOne, subjectTwo *Subject) {
-	reporter, ctrl = createFixtures(t)
-
-	subjectOne = new(Subject)
-	subjectTwo = new(Subject)
-
-	gomock.InOrder(
-		ctrl.RecordCall(subjectOne, "FooMethod", "1").AnyTimes(),
-		ctrl.RecordCall(subjectTwo, "FooMethod", "2"),
-		ctrl.RecordCall(subjectTwo, "BarMethod", "3"),
-	)
+func TestReturnPtrWithBadType(t *testing.T) {
+	rep, ctrl := createFixtures(t)
+	defer ctrl.Finish()
 
-	return
+	s := new(Subject)
+	str := "blah"
+	rep.assertFatal(func() {
+		ctrl.RecordCall(s, "FooMethod", "1").ReturnPtr(&str)
+	}, "wrong type of argument 0 to ReturnPtr", "string is not assignable to int")
+	rep.assertFatal(func() {
+		ctrl.RecordCall(s, "FooMethod", "1").ReturnPtr(5)
+	}, "argument 0 to ReturnPtr", "not a non-nil pointer")
+	ctrl.Call(s, "FooMethod", "1")
+	ctrl.Call(s, "FooMethod", "1")
 }
 
-func TestOrderedCallsCorrect(t *testing.T) {
-	reporter, ctrl, subjectOne, subjectTwo := commonTestOrderedCalls(t)
+func TestFailNThenSucceed(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
 
-	ctrl.Call(subjectOne, "FooMethod", "1")
-	ctrl.Call(subjectTwo, "FooMethod", "2")
-	ctrl.Call(subjectTwo, "BarMethod", "3")
+	errTemporary := errors.New("temporary")
+	ctrl.RecordCall(subject, "FetchMethod", "key").FailNThenSucceed(3, errTemporary, 42, nil)
 
+	for i := 0; i < 3; i++ {
+		assertEqual(t, []interface{}{0, errTemporary}, ctrl.Call(subject, "FetchMethod", "key"))
+	}
+	assertEqual(t, []interface{}{42, nil}, ctrl.Call(subject, "FetchMethod", "key"))
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FetchMethod", "key")
+	}, "has already been called the max number of times")
 	ctrl.Finish()
+}
 
-	reporter.assertPass("After finish")
+func TestFailNThenSucceedWithBadType(t *testing.T) {
+	rep, ctrl := createFixtures(t)
+	defer ctrl.Finish()
+
+	s := new(Subject)
+	rep.assertFatal(func() {
+		ctrl.RecordCall(s, "FooMethod", "1").FailNThenSucceed(1, errors.New("err"), 5)
+	}, "whose last return value is not an error")
+	rep.assertFatal(func() {
+		ctrl.RecordCall(s, "FetchMethod", "1").FailNThenSucceed(1, errors.New("err"), "5", nil)
+	}, "wrong type of argument 0 to FailNThenSucceed")
+	ctrl.Call(s, "FooMethod", "1")
+	ctrl.Call(s, "FetchMethod", "1")
 }
 
-func TestOrderedCallsInCorrect(t *testing.T) {
-	reporter, ctrl, subjectOne, subjectTwo := commonTestOrderedCalls(t)
+func TestExpectWithin(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
 
-	ctrl.Call(subjectOne, "FooMethod", "1")
-	reporter.assertFatal(func() {
-		// FooMethod(2) should be called before BarMethod(3)
-		ctrl.Call(subjectTwo, "BarMethod", "3")
-	}, "Unexpected call to", "Subject.BarMethod([3])", "doesn't have a prerequisite call satisfied")
+	ctrl.RecordCall(subject, "FooMethod", "fast").ExpectWithin(time.Hour)
+	ctrl.RecordCall(subject, "FooMethod", "slow").ExpectWithin(time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	ctrl.Call(subject, "FooMethod", "fast")
+	ctrl.Call(subject, "FooMethod", "slow")
+	ctrl.Finish()
+
+	reporter.assertPass("late calls only cause warnings")
+	if len(reporter.log) != 1 {
+		t.Fatalf("expected 1 warning, got %q", reporter.log)
+	}
+	if !strings.Contains(reporter.log[0], "FooMethod(is equal to slow)") ||
+		!strings.Contains(reporter.log[0], "later than the expected 1ms") {
+		t.Errorf("unexpected warning: %q", reporter.log[0])
+	}
 }
 
-// Test that calls that are prerequisites to other calls but have maxCalls >
-// minCalls are removed from the expected call set.
-func TestOrderedCallsWithPreReqMaxUnbounded(t *testing.T) {
-	reporter, ctrl, subjectOne, subjectTwo := commonTestOrderedCalls(t)
+func TestExpectWithin_ReporterWithoutLogf(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(&HelperReporter{TestReporter: reporter})
+	subject := new(Subject)
 
-	// Initially we should be able to call FooMethod("1") as many times as we
-	// want.
-	ctrl.Call(subjectOne, "FooMethod", "1")
-	ctrl.Call(subjectOne, "FooMethod", "1")
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
 
-	// But calling something that has it as a prerequite should remove it from
-	// the expected call set. This allows tests to ensure that FooMethod("1") is
-	// *not* called after FooMethod("2").
-	ctrl.Call(subjectTwo, "FooMethod", "2")
+	ctrl.RecordCall(subject, "FooMethod", "slow").ExpectWithin(time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	ctrl.Call(subject, "FooMethod", "slow")
+	ctrl.Finish()
 
-	// Therefore this call should fail:
-	reporter.assertFatal(func() {
-		ctrl.Call(subjectOne, "FooMethod", "1")
-	})
+	reporter.assertPass("late call without a Logf method")
+	if len(reporter.log) != 0 {
+		t.Errorf("expected the warning not to be reported, got %q", reporter.log)
+	}
+	if got := buf.String(); !strings.Contains(got, "gomock: call to *gomock_test.Subject.FooMethod(is equal to slow)") ||
+		!strings.Contains(got, "later than the expected 1ms") {
+		t.Errorf("standard logger got %q, want the late call warning", got)
+	}
 }
 
-func TestCallAfterLoopPanic(t *testing.T) {
-	_, ctrl := createFixtures(t)
-
+func TestRecordIntervals(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
 	subject := new(Subject)
 
-	firstCall := ctrl.RecordCall(subject, "FooMethod", "1")
-	secondCall := ctrl.RecordCall(subject, "FooMethod", "2")
-	thirdCall := ctrl.RecordCall(subject, "FooMethod", "3")
+	call := ctrl.RecordCall(subject, "FetchMethod", "key").RecordIntervals().Times(4).
+		Return(0, errors.New("unavailable"))
+	if got := call.Intervals(); len(got) != 0 {
+		t.Errorf("Intervals() = %v before any call, want none", got)
+	}
 
-	gomock.InOrder(firstCall, secondCall, thirdCall)
+	// A retry loop with exponential backoff.
+	backoff := []time.Duration{time.Millisecond, 25 * time.Millisecond, 75 * time.Millisecond}
+	for _, d := range backoff {
+		ctrl.Call(subject, "FetchMethod", "key")
+		time.Sleep(d)
+	}
+	ctrl.Call(subject, "FetchMethod", "key")
 
-	defer func() {
-		err := recover()
-		if err == nil {
-			t.Error("Call.After creation of dependency loop did not panic.")
+	intervals := call.Intervals()
+	if len(intervals) != len(backoff) {
+		t.Fatalf("Intervals() = %v, want %d intervals", intervals, len(backoff))
+	}
+	for i, d := range intervals {
+		if d < backoff[i] {
+			t.Errorf("interval %d = %v, shorter than the backoff of %v", i, d, backoff[i])
 		}
-	}()
-
-	// This should panic due to dependency loop.
-	firstCall.After(thirdCall)
+		if i > 0 && d <= intervals[i-1] {
+			t.Errorf("intervals %v are not increasing", intervals)
+		}
+	}
+	ctrl.Finish()
+	reporter.assertPass("retries with backoff")
 }
 
-func TestPanicOverridesExpectationChecks(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	reporter := NewErrorReporter(t)
+func TestMinInterval(t *testing.T) {
+	t.Run("Spaced", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
 
-	reporter.assertFatal(func() {
-		ctrl.RecordCall(new(Subject), "FooMethod", "1")
-		defer ctrl.Finish()
-		reporter.Fatalf("Intentional panic")
+		ctrl.RecordCall(subject, "FooMethod", "1").MinInterval(5 * time.Millisecond).Times(2)
+		ctrl.Call(subject, "FooMethod", "1")
+		time.Sleep(10 * time.Millisecond)
+		ctrl.Call(subject, "FooMethod", "1")
+		ctrl.Finish()
+		reporter.assertPass("calls spaced by more than the minimum interval")
 	})
-}
 
-func TestSetArgWithBadType(t *testing.T) {
-	rep, ctrl := createFixtures(t)
-	defer ctrl.Finish()
+	t.Run("Rapid", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
 
-	s := new(Subject)
-	// This should catch a type error:
-	rep.assertFatal(func() {
-		ctrl.RecordCall(s, "FooMethod", "1").SetArg(0, "blah")
+		ctrl.RecordCall(subject, "FooMethod", "1").MinInterval(time.Hour).Times(2)
+		ctrl.Call(subject, "FooMethod", "1")
+		ctrl.Call(subject, "FooMethod", "1")
+		ctrl.Finish()
+		reporter.assertFail("calls closer than the minimum interval")
+		if len(reporter.log) != 1 || !strings.Contains(reporter.log[0], "sooner than the minimum interval of 1h0m0s") {
+			t.Errorf("unexpected errors: %q", reporter.log)
+		}
+	})
+}
+
+func TestAssertArgHistogram(t *testing.T) {
+	atLeastTenPercentHigh := func(counts map[interface{}]int) error {
+		high, total := counts["high"], 0
+		for _, n := range counts {
+			total += n
+		}
+		if high*10 < total {
+			return fmt.Errorf("only %d of %d calls had high priority", high, total)
+		}
+		return nil
+	}
+	send := func(ctrl *gomock.Controller, subject *Subject, high, low int) {
+		for i := 0; i < high; i++ {
+			ctrl.Call(subject, "FooMethod", "high")
+		}
+		for i := 0; i < low; i++ {
+			ctrl.Call(subject, "FooMethod", "low")
+		}
+	}
+
+	t.Run("Satisfied", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "FooMethod", gomock.Any()).AnyTimes().AssertArgHistogram(0, atLeastTenPercentHigh)
+		send(ctrl, subject, 2, 18)
+		ctrl.Finish()
+		reporter.assertPass("distribution constraint satisfied")
+	})
+
+	t.Run("Violated", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "FooMethod", gomock.Any()).AnyTimes().AssertArgHistogram(0, atLeastTenPercentHigh)
+		send(ctrl, subject, 1, 19)
+		ctrl.Finish()
+		reporter.assertFail("distribution constraint violated")
+		if len(reporter.log) != 1 || !strings.Contains(reporter.log[0], "histogram of argument 0") ||
+			!strings.Contains(reporter.log[0], "only 1 of 20 calls had high priority") {
+			t.Errorf("unexpected errors: %q", reporter.log)
+		}
+	})
+
+	t.Run("UncomparableValues", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		var got map[interface{}]int
+		ctrl.RecordCall(subject, "SetArgMethod", gomock.Any(), gomock.Any()).Times(2).
+			AssertArgHistogram(0, func(counts map[interface{}]int) error {
+				got = counts
+				return nil
+			})
+		ctrl.Call(subject, "SetArgMethod", []byte{1}, nil)
+		ctrl.Call(subject, "SetArgMethod", []byte{1}, nil)
+		ctrl.Finish()
+		reporter.assertPass("slices counted by their formatting")
+		if want := map[interface{}]int{"[1]": 2}; !reflect.DeepEqual(got, want) {
+			t.Errorf("counts = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("InvalidIndex", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		reporter.assertFatal(func() {
+			ctrl.RecordCall(subject, "FooMethod", "1").AssertArgHistogram(1, atLeastTenPercentHigh)
+		}, "AssertArgHistogram(1) called for a method with 1 args")
+	})
+}
+
+func TestAssertArgUnique(t *testing.T) {
+	t.Run("Unique", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "VariadicMethod", gomock.Any()).AnyTimes().AssertArgUnique(0)
+		for i := 0; i < 3; i++ {
+			ctrl.Call(subject, "VariadicMethod", i)
+		}
+		ctrl.Finish()
+		reporter.assertPass("unique arguments")
+	})
+
+	t.Run("Duplicates", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "FooMethod", gomock.Any()).AnyTimes().AssertArgUnique(0)
+		for _, id := range []string{"req-1", "req-2", "req-1", "req-3", "req-2", "req-1"} {
+			ctrl.Call(subject, "FooMethod", id)
+		}
+		ctrl.Finish()
+		reporter.assertFail("duplicate arguments")
+		if len(reporter.log) != 1 || !strings.Contains(reporter.log[0], "histogram of argument 0") ||
+			!strings.Contains(reporter.log[0], "values must be unique, but got duplicates req-1 (3 times), req-2 (2 times)") {
+			t.Errorf("unexpected errors: %q", reporter.log)
+		}
+	})
+
+	t.Run("InvalidIndex", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		reporter.assertFatal(func() {
+			ctrl.RecordCall(subject, "FooMethod", "1").AssertArgUnique(-1)
+		}, "AssertArgUnique(-1) called for a method with 1 args")
+	})
+}
+
+func TestTimesRange(t *testing.T) {
+	t.Run("WithinRange", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "FooMethod", "1").TimesRange(2, 4)
+		for i := 0; i < 3; i++ {
+			ctrl.Call(subject, "FooMethod", "1")
+		}
+		ctrl.Finish()
+		reporter.assertPass("calls within range")
+	})
+
+	t.Run("TooFew", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "FooMethod", "1").TimesRange(2, 4)
+		ctrl.Call(subject, "FooMethod", "1")
+		reporter.assertFatal(func() {
+			ctrl.Finish()
+		}, "aborting test due to missing call(s)")
+		if got := reporter.log[0]; !strings.Contains(got, "missing call(s) to") || !strings.Contains(got, "expected between 2 and 4 calls, got 1") {
+			t.Errorf("unexpected error: %q", got)
+		}
+	})
+
+	t.Run("TooMany", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "FooMethod", "1").TimesRange(2, 4)
+		for i := 0; i < 4; i++ {
+			ctrl.Call(subject, "FooMethod", "1")
+		}
+		reporter.assertFatal(func() {
+			ctrl.Call(subject, "FooMethod", "1")
+		}, "Unexpected call to", "expected between 2 and 4 calls, got 5")
+	})
+
+	t.Run("InvalidRange", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		reporter.assertFatal(func() {
+			ctrl.RecordCall(subject, "FooMethod", "1").TimesRange(4, 2)
+		}, "TimesRange(4, 2) called with an invalid range")
+	})
+
+	t.Run("After", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		first := ctrl.RecordCall(subject, "FooMethod", "1").TimesRange(2, 3)
+		ctrl.RecordCall(subject, "BarMethod", "2").After(first)
+
+		ctrl.Call(subject, "FooMethod", "1")
+		reporter.assertFatal(func() {
+			ctrl.Call(subject, "BarMethod", "2")
+		}, "Unexpected call to", "doesn't have a prerequisite call satisfied")
+		ctrl.Call(subject, "FooMethod", "1")
+		ctrl.Call(subject, "BarMethod", "2")
+		ctrl.Finish()
+		if len(reporter.log) != 1 {
+			t.Errorf("expected only the early call to fail, got %q", reporter.log)
+		}
+	})
+}
+
+func TestUnorderedCalls(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	subjectTwo := new(Subject)
+	subjectOne := new(Subject)
+
+	ctrl.RecordCall(subjectOne, "FooMethod", "1")
+	ctrl.RecordCall(subjectOne, "BarMethod", "2")
+	ctrl.RecordCall(subjectTwo, "FooMethod", "3")
+	ctrl.RecordCall(subjectTwo, "BarMethod", "4")
+
+	// Make the calls in a different order, which should be fine.
+	ctrl.Call(subjectOne, "BarMethod", "2")
+	ctrl.Call(subjectTwo, "FooMethod", "3")
+	ctrl.Call(subjectTwo, "BarMethod", "4")
+	ctrl.Call(subjectOne, "FooMethod", "1")
+
+	reporter.assertPass("After making all calls in different order")
+
+	ctrl.Finish()
+
+	reporter.assertPass("After finish")
+}
+
+func commonTestOrderedCalls(t *testing.T) (reporter *ErrorReporter, ctrl *gomock.Controller, subjectOne, subjectTwo *Subject) {
+	reporter, ctrl = createFixtures(t)
+
+	subjectOne = new(Subject)
+	subjectTwo = new(Subject)
+
+	gomock.InOrder(
+		ctrl.RecordCall(subjectOne, "FooMethod", "1").AnyTimes(),
+		ctrl.RecordCall(subjectTwo, "FooMethod", "2"),
+		ctrl.RecordCall(subjectTwo, "BarMethod", "3"),
+	)
+
+	return
+}
+
+func TestOrderedCallsCorrect(t *testing.T) {
+	reporter, ctrl, subjectOne, subjectTwo := commonTestOrderedCalls(t)
+
+	ctrl.Call(subjectOne, "FooMethod", "1")
+	ctrl.Call(subjectTwo, "FooMethod", "2")
+	ctrl.Call(subjectTwo, "BarMethod", "3")
+
+	ctrl.Finish()
+
+	reporter.assertPass("After finish")
+}
+
+func TestOrderedCallsInCorrect(t *testing.T) {
+	reporter, ctrl, subjectOne, subjectTwo := commonTestOrderedCalls(t)
+
+	ctrl.Call(subjectOne, "FooMethod", "1")
+	reporter.assertFatal(func() {
+		// FooMethod(2) should be called before BarMethod(3)
+		ctrl.Call(subjectTwo, "BarMethod", "3")
+	}, "Unexpected call to", "Subject.BarMethod([3])", "doesn't have a prerequisite call satisfied")
+}
+
+// Test that calls that are prerequisites to other calls but have maxCalls >
+// minCalls are removed from the expected call set.
+func TestOrderedCallsWithPreReqMaxUnbounded(t *testing.T) {
+	reporter, ctrl, subjectOne, subjectTwo := commonTestOrderedCalls(t)
+
+	// Initially we should be able to call FooMethod("1") as many times as we
+	// want.
+	ctrl.Call(subjectOne, "FooMethod", "1")
+	ctrl.Call(subjectOne, "FooMethod", "1")
+
+	// But calling something that has it as a prerequite should remove it from
+	// the expected call set. This allows tests to ensure that FooMethod("1") is
+	// *not* called after FooMethod("2").
+	ctrl.Call(subjectTwo, "FooMethod", "2")
+
+	// Therefore this call should fail:
+	reporter.assertFatal(func() {
+		ctrl.Call(subjectOne, "FooMethod", "1")
+	})
+}
+
+func TestCallAfterLoopPanic(t *testing.T) {
+	_, ctrl := createFixtures(t)
+
+	subject := new(Subject)
+
+	firstCall := ctrl.RecordCall(subject, "FooMethod", "1")
+	secondCall := ctrl.RecordCall(subject, "FooMethod", "2")
+	thirdCall := ctrl.RecordCall(subject, "FooMethod", "3")
+
+	gomock.InOrder(firstCall, secondCall, thirdCall)
+
+	defer func() {
+		err := recover()
+		if err == nil {
+			t.Error("Call.After creation of dependency loop did not panic.")
+		}
+	}()
+
+	// This should panic due to dependency loop.
+	firstCall.After(thirdCall)
+}
+
+func TestPanicOverridesExpectationChecks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	reporter := NewErrorReporter(t)
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(new(Subject), "FooMethod", "1")
+		defer ctrl.Finish()
+		reporter.Fatalf("Intentional panic")
+	})
+}
+
+func TestRequireArgWritten(t *testing.T) {
+	t.Run("Written", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "SetArgMethod", gomock.Any(), gomock.Any()).
+			Do(func(sliceArg []byte, ptrArg *int) { *ptrArg = 42 }).
+			RequireArgWritten(1)
+		ctrl.RecordCall(subject, "SetArgMethod", gomock.Any(), gomock.Any()).
+			SetArg(0, []byte{1}).
+			RequireArgWritten(0)
+
+		in := 43
+		ctrl.Call(subject, "SetArgMethod", []byte(nil), &in)
+		ctrl.Call(subject, "SetArgMethod", []byte{0}, &in)
+		ctrl.Finish()
+		reporter.assertPass("arguments written")
+	})
+
+	t.Run("Forgotten", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "SetArgMethod", gomock.Any(), gomock.Any()).
+			Do(func(sliceArg []byte, ptrArg *int) {}).
+			RequireArgWritten(1)
+
+		in := 43
+		ctrl.Call(subject, "SetArgMethod", []byte(nil), &in)
+		reporter.assertFail("argument not written")
+		if got := reporter.log[len(reporter.log)-1]; !strings.Contains(got, "argument 1 of call") || !strings.Contains(got, "was not written by its actions; it is still 43") {
+			t.Errorf("unexpected error message: %q", got)
+		}
+	})
+
+	t.Run("NotAPointer", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		reporter.assertFatal(func() {
+			ctrl.RecordCall(subject, "FooMethod", "1").RequireArgWritten(0)
+		}, "RequireArgWritten(0) referring to argument of non-pointer non-interface non-slice type string")
+	})
+
+	t.Run("Variadic", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		reporter.assertFatal(func() {
+			ctrl.RecordCall(subject, "VariadicMethod", 0).RequireArgWritten(1)
+		}, "RequireArgWritten(1) referring to the variadic argument of *gomock_test.Subject.VariadicMethod")
+	})
+
+	t.Run("Untrackable", func(t *testing.T) {
+		for _, arg := range []interface{}{[]byte(nil), []byte{}, (*int)(nil), nil} {
+			reporter, ctrl := createFixtures(t)
+			subject := new(Subject)
+
+			ctrl.RecordCall(subject, "DecodeMethod", gomock.Any()).
+				Do(func(dst interface{}) {}).
+				RequireArgWritten(0)
+
+			ctrl.Call(subject, "DecodeMethod", arg)
+			reporter.assertFail(fmt.Sprintf("argument %#v cannot be written", arg))
+			if got := reporter.log[len(reporter.log)-1]; !strings.Contains(got, "is not a non-nil pointer or a non-empty slice") {
+				t.Errorf("unexpected error message for %#v: %q", arg, got)
+			}
+		}
+	})
+
+	t.Run("PointeeReplaced", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "DecodeMethod", gomock.Any()).
+			Do(func(dst interface{}) { *dst.(*map[string]int) = map[string]int{"a": 1} }).
+			RequireArgWritten(0)
+		ctrl.RecordCall(subject, "DecodeMethod", gomock.Any()).
+			Do(func(dst interface{}) { *dst.(*[]int) = nil }).
+			RequireArgWritten(0)
+
+		m := map[string]int{"a": 1}
+		ctrl.Call(subject, "DecodeMethod", &m)
+		ints := []int{}
+		ctrl.Call(subject, "DecodeMethod", &ints)
+		ctrl.Finish()
+		reporter.assertPass("pointees replaced with equal contents")
+	})
+
+	t.Run("PointeeKept", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "DecodeMethod", gomock.Any()).
+			Do(func(dst interface{}) {}).
+			RequireArgWritten(0)
+
+		var m map[string]int
+		ctrl.Call(subject, "DecodeMethod", &m)
+		reporter.assertFail("nil map not written")
+		if got := reporter.log[len(reporter.log)-1]; !strings.Contains(got, "was not written by its actions") {
+			t.Errorf("unexpected error message: %q", got)
+		}
+	})
+}
+
+func TestSetArgWithBadType(t *testing.T) {
+	rep, ctrl := createFixtures(t)
+	defer ctrl.Finish()
+
+	s := new(Subject)
+	// This should catch a type error:
+	rep.assertFatal(func() {
+		ctrl.RecordCall(s, "FooMethod", "1").SetArg(0, "blah")
 	})
 	ctrl.Call(s, "FooMethod", "1")
 }
@@ -796,20 +1551,1238 @@ func TestDuplicateFinishCallFails(t *testing.T) {
 	rep.assertFatal(ctrl.Finish, "Controller.Finish was called more than once. It has to be called exactly once.")
 }
 
-func TestNoHelper(t *testing.T) {
-	ctrlNoHelper := gomock.NewController(NewErrorReporter(t))
+func TestCallHook(t *testing.T) {
+	type hookCall struct {
+		method string
+		args   []interface{}
+	}
+	var calls []hookCall
 
-	// doesn't panic
-	ctrlNoHelper.T.Helper()
+	reporter := NewErrorReporter(t)
+	var ctrl *gomock.Controller
+	ctrl = gomock.NewController(reporter, gomock.WithCallHook(func(method string, args []interface{}) {
+		calls = append(calls, hookCall{method, args})
+		// The hook must be able to use the controller without deadlocking.
+		ctrl.RecordCall(new(Subject), "BarMethod", "from hook").AnyTimes()
+	}))
+	defer reporter.recoverUnexpectedFatal()
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1")
+	ctrl.RecordCall(subject, "BarMethod", "2").Times(2)
+
+	ctrl.Call(subject, "FooMethod", "1")
+	ctrl.Call(subject, "BarMethod", "2")
+	ctrl.Call(subject, "BarMethod", "2")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "unexpected")
+	})
+	ctrl.Finish()
+
+	assertEqual(t, []hookCall{
+		{"FooMethod", []interface{}{"1"}},
+		{"BarMethod", []interface{}{"2"}},
+		{"BarMethod", []interface{}{"2"}},
+	}, calls)
 }
 
-func TestWithHelper(t *testing.T) {
-	withHelper := &HelperReporter{TestReporter: NewErrorReporter(t)}
-	ctrlWithHelper := gomock.NewController(withHelper)
+func TestLifecycleLogger(t *testing.T) {
+	var events []string
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithLifecycleLogger(func(event, method string) {
+		events = append(events, event+" "+method)
+	}))
+	subject := new(Subject)
 
-	ctrlWithHelper.T.Helper()
+	ctrl.RecordCall(subject, "FooMethod", "argument")
+	ctrl.RecordCall(subject, "BarMethod", "argument")
+	ctrl.Call(subject, "FooMethod", "argument")
+	reporter.assertFatal(ctrl.Finish, "aborting test due to missing call(s)")
+
+	assertEqual(t, []string{
+		"created FooMethod",
+		"created BarMethod",
+		"matched FooMethod",
+		"exhausted FooMethod",
+		"unmet BarMethod",
+	}, events)
+}
 
-	if withHelper.helper == 0 {
-		t.Fatal("expected Helper to be invoked")
+func TestMaxTotalCalls(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithMaxTotalCalls(3))
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "argument").AnyTimes()
+	ctrl.RecordCall(subject, "BarMethod", "argument").AnyTimes()
+	ctrl.Call(subject, "FooMethod", "argument")
+	ctrl.Call(subject, "BarMethod", "argument")
+	ctrl.Call(subject, "FooMethod", "argument")
+	reporter.assertPass("calls up to the limit")
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "BarMethod", "argument")
+	}, "Too many calls: call 4 to *gomock_test.Subject.BarMethod([argument])", "exceeds the limit of 3 matched calls")
+}
+
+func TestShuffleMatching(t *testing.T) {
+	// Without InOrder, some seed consumes the later of two expectations that
+	// match the same call first.
+	shuffled := false
+	for seed := int64(0); seed < 20 && !shuffled; seed++ {
+		ctrl := gomock.NewController(NewErrorReporter(t), gomock.WithShuffleMatching(seed))
+		subject := new(Subject)
+		ctrl.RecordCall(subject, "FooMethod", gomock.Any()).Return(1)
+		ctrl.RecordCall(subject, "FooMethod", gomock.Any()).Return(2)
+		shuffled = ctrl.Call(subject, "FooMethod", "1")[0] == 2
+	}
+	if !shuffled {
+		t.Error("no seed tried the expected calls out of order")
 	}
+
+	// With InOrder, every seed consumes them in order.
+	for seed := int64(0); seed < 20; seed++ {
+		reporter := NewErrorReporter(t)
+		ctrl := gomock.NewController(reporter, gomock.WithShuffleMatching(seed))
+		subject := new(Subject)
+		var calls []*gomock.Call
+		for i := 1; i <= 5; i++ {
+			calls = append(calls, ctrl.RecordCall(subject, "FooMethod", gomock.Any()).Return(i))
+		}
+		gomock.InOrder(calls...)
+
+		for i := 1; i <= 5; i++ {
+			if got := ctrl.Call(subject, "FooMethod", "1")[0]; got != i {
+				t.Errorf("seed %d: call %d returned %v, want %d", seed, i, got, i)
+			}
+		}
+		ctrl.Finish()
+		reporter.assertPass("calls in order under shuffling")
+	}
+}
+
+func TestAssertOrder(t *testing.T) {
+	t.Run("InOrder", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		first, second := new(NamedSubject), new(NamedSubject)
+
+		a := ctrl.RecordCall(first, "FooMethod", "1").Times(2)
+		b := ctrl.RecordCall(second, "BarMethod", "2").Times(2)
+		unmatched := ctrl.RecordCall(second, "FooMethod", "3").Optional()
+		ctrl.AssertOrder(a, b)
+		ctrl.AssertOrder(b, unmatched)
+		ctrl.RecordCall(first, "BarMethod", "4")
+
+		ctrl.Call(first, "BarMethod", "4")
+		ctrl.Call(first, "FooMethod", "1")
+		ctrl.Call(first, "FooMethod", "1")
+		ctrl.Call(second, "BarMethod", "2")
+		ctrl.Call(second, "BarMethod", "2")
+		ctrl.Finish()
+		reporter.assertPass("calls in asserted order")
+	})
+
+	t.Run("OutOfOrder", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		first, second := new(NamedSubject), new(NamedSubject)
+
+		a := ctrl.RecordCall(first, "FooMethod", "1").Times(2)
+		b := ctrl.RecordCall(second, "BarMethod", "2")
+		ctrl.AssertOrder(a, b)
+
+		ctrl.Call(first, "FooMethod", "1")
+		ctrl.Call(second, "BarMethod", "2")
+		ctrl.Call(first, "FooMethod", "1")
+		ctrl.Finish()
+		reporter.assertFail("call matched after the call it must precede")
+		if got := reporter.log[len(reporter.log)-1]; !strings.Contains(got, "FooMethod(is equal to 1)") || !strings.Contains(got, "must precede it") {
+			t.Errorf("unexpected error message: %q", got)
+		}
+	})
+}
+
+func TestAllOrNothing(t *testing.T) {
+	setup := func(t *testing.T) (*ErrorReporter, *gomock.Controller, *Subject) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+		gomock.AllOrNothing(
+			ctrl.RecordCall(subject, "FooMethod", "begin"),
+			ctrl.RecordCall(subject, "BarMethod", "commit").Times(2),
+		)
+		return reporter, ctrl, subject
+	}
+
+	t.Run("AllSatisfied", func(t *testing.T) {
+		reporter, ctrl, subject := setup(t)
+		ctrl.Call(subject, "FooMethod", "begin")
+		ctrl.Call(subject, "BarMethod", "commit")
+		ctrl.Call(subject, "BarMethod", "commit")
+		ctrl.Finish()
+		reporter.assertPass("all grouped calls satisfied")
+	})
+
+	t.Run("None", func(t *testing.T) {
+		reporter, ctrl, _ := setup(t)
+		ctrl.Finish()
+		reporter.assertPass("no grouped call matched")
+	})
+
+	t.Run("Partial", func(t *testing.T) {
+		reporter, ctrl, subject := setup(t)
+		ctrl.Call(subject, "FooMethod", "begin")
+		ctrl.Call(subject, "BarMethod", "commit")
+		reporter.assertFatal(func() {
+			ctrl.Finish()
+		}, "aborting test due to missing call(s)")
+		want := "only 1 of 2 calls grouped by AllOrNothing were satisfied"
+		if !strings.Contains(strings.Join(reporter.log, "\n"), want) {
+			t.Errorf("log %q does not contain %q", reporter.log, want)
+		}
+	})
+
+	t.Run("NoneAmongOtherMissingCalls", func(t *testing.T) {
+		reporter, ctrl, subject := setup(t)
+		ctrl.RecordCall(subject, "FooMethod", "other")
+		reporter.assertFatal(func() {
+			ctrl.Finish()
+		}, "aborting test due to missing call(s)")
+		if log := strings.Join(reporter.log, "\n"); strings.Contains(log, "begin") || strings.Contains(log, "commit") {
+			t.Errorf("unmatched grouped calls reported missing: %q", reporter.log)
+		}
+	})
+
+	t.Run("DifferentControllers", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		otherCtrl := gomock.NewController(reporter)
+		subject := new(Subject)
+		reporter.assertFatal(func() {
+			gomock.AllOrNothing(
+				ctrl.RecordCall(subject, "FooMethod", "begin"),
+				otherCtrl.RecordCall(subject, "BarMethod", "commit"),
+			)
+		}, "AllOrNothing called with calls of different controllers")
+	})
+
+	t.Run("NoCalls", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "gomock: AllOrNothing called without any calls" {
+				t.Errorf("recover() = %v, want a panic about the missing calls", r)
+			}
+		}()
+		gomock.AllOrNothing()
+	})
+}
+
+// A type with a field, so that distinct values have distinct addresses.
+type NamedSubject struct {
+	Subject
+	id int
+}
+
+func TestOrderExpectations(t *testing.T) {
+	t.Run("InOrder", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject, other := &NamedSubject{id: 1}, &NamedSubject{id: 2}
+
+		ctrl.OrderExpectations(subject)
+		ctrl.RecordCall(subject, "FooMethod", "open")
+		ctrl.RecordCall(subject, "FooMethod", gomock.Any()).AnyTimes()
+		ctrl.RecordCall(subject, "BarMethod", "close")
+		// Other mocks are not ordered.
+		ctrl.RecordCall(other, "BarMethod", "2")
+		ctrl.RecordCall(other, "BarMethod", "1")
+
+		ctrl.Call(other, "BarMethod", "1")
+		ctrl.Call(subject, "FooMethod", "open")
+		ctrl.Call(subject, "FooMethod", "read")
+		ctrl.Call(subject, "FooMethod", "read")
+		ctrl.Call(subject, "BarMethod", "close")
+		ctrl.Call(other, "BarMethod", "2")
+		ctrl.Finish()
+		reporter.assertPass("calls in declared order")
+	})
+
+	t.Run("OutOfOrder", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		ctrl.OrderExpectations(subject)
+		ctrl.RecordCall(subject, "FooMethod", "open")
+		ctrl.RecordCall(subject, "BarMethod", "close")
+
+		reporter.assertFatal(func() {
+			ctrl.Call(subject, "BarMethod", "close")
+		}, "Unexpected call to *gomock_test.Subject.BarMethod([close])", "because: ",
+			"doesn't have a prerequisite call satisfied")
+	})
+
+	t.Run("AnyTimesAfterLater", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		ctrl.OrderExpectations(subject)
+		ctrl.RecordCall(subject, "FooMethod", "read").AnyTimes()
+		ctrl.RecordCall(subject, "BarMethod", "close")
+
+		ctrl.Call(subject, "BarMethod", "close")
+		reporter.assertFatal(func() {
+			ctrl.Call(subject, "FooMethod", "read")
+		}, "Unexpected call to *gomock_test.Subject.FooMethod([read])")
+	})
+
+	t.Run("WithAfter", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject, other := &NamedSubject{id: 1}, &NamedSubject{id: 2}
+
+		first := ctrl.RecordCall(other, "FooMethod", "1")
+		ctrl.OrderExpectations(subject)
+		ctrl.RecordCall(subject, "FooMethod", "2").After(first)
+		ctrl.RecordCall(subject, "FooMethod", "3")
+
+		reporter.assertFatal(func() {
+			ctrl.Call(subject, "FooMethod", "2")
+		}, "doesn't have a prerequisite call satisfied")
+		ctrl.Call(other, "FooMethod", "1")
+		ctrl.Call(subject, "FooMethod", "2")
+		ctrl.Call(subject, "FooMethod", "3")
+	})
+}
+
+func TestPendingExpectations(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+	named := &NamedSubject{id: 1}
+	ctrl.NameMock(named, "primary")
+
+	if pending := ctrl.PendingExpectations(); len(pending) != 0 {
+		t.Errorf("PendingExpectations() = %v without expected calls, want none", pending)
+	}
+
+	ctrl.RecordCall(subject, "FooMethod", "1").MinTimes(2)
+	ctrl.RecordCall(subject, "BarMethod", gomock.Any()).AnyTimes()
+	ctrl.RecordCall(named, "FooMethod", "2")
+	ctrl.Call(subject, "FooMethod", "1")
+
+	pending := ctrl.PendingExpectations()
+	for i := range pending {
+		if !strings.Contains(pending[i].Origin, "controller_test.go:") {
+			t.Errorf("Origin = %q, want the recording line", pending[i].Origin)
+		}
+		pending[i].Origin = ""
+	}
+	want := []gomock.ExpectationInfo{
+		{Receiver: "*gomock_test.NamedSubject", Name: "primary", Method: "FooMethod", Args: []string{"is equal to 2"}, MinCalls: 1, MaxCalls: 1},
+		{Receiver: "*gomock_test.Subject", Method: "FooMethod", Args: []string{"is equal to 1"}, MinCalls: 2, MaxCalls: -1, NumCalls: 1},
+	}
+	if !reflect.DeepEqual(pending, want) {
+		t.Errorf("PendingExpectations() = %+v, want %+v", pending, want)
+	}
+
+	// The returned values are copies.
+	pending[0].NumCalls = 5
+	ctrl.Call(named, "FooMethod", "2")
+	ctrl.Call(subject, "FooMethod", "1")
+	if pending := ctrl.PendingExpectations(); len(pending) != 0 {
+		t.Errorf("PendingExpectations() = %+v after all calls, want none", pending)
+	}
+	ctrl.Finish()
+	reporter.assertPass("all expectations met")
+}
+
+func TestPendingExpectations_AllOrNothing(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	gomock.AllOrNothing(
+		ctrl.RecordCall(subject, "FooMethod", "begin"),
+		ctrl.RecordCall(subject, "BarMethod", "commit"),
+	)
+	if pending := ctrl.PendingExpectations(); len(pending) != 0 {
+		t.Errorf("PendingExpectations() = %+v with an untouched AllOrNothing group, want none", pending)
+	}
+
+	ctrl.Call(subject, "FooMethod", "begin")
+	pending := ctrl.PendingExpectations()
+	if len(pending) != 1 || pending[0].Method != "BarMethod" {
+		t.Errorf("PendingExpectations() = %+v with a partially matched AllOrNothing group, want BarMethod", pending)
+	}
+
+	ctrl.Call(subject, "BarMethod", "commit")
+	ctrl.Finish()
+	reporter.assertPass("AllOrNothing group satisfied")
+}
+
+func TestCaptor(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	captor := gomock.NewCaptor()
+	ctrl.RecordCall(subject, "FetchMethod", "skip").Return(0, nil)
+	ctrl.RecordCall(subject, "FetchMethod", captor).Times(3)
+	ctrl.RecordCall(subject, "CopyMethod", captor, "b")
+
+	ctrl.Call(subject, "FetchMethod", "skip")
+	ctrl.Call(subject, "FetchMethod", "first")
+	// Matched by the captor, but not by the expected call.
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "CopyMethod", "c", "c")
+	})
+	ctrl.Call(subject, "FetchMethod", "second")
+	ctrl.Call(subject, "CopyMethod", "a", "b")
+	ctrl.Call(subject, "FetchMethod", "third")
+
+	if got, want := captor.Values(), []interface{}{"first", "second", "a", "third"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+	if got := captor.Value(1); got != "second" {
+		t.Errorf("Value(1) = %v, want second", got)
+	}
+	ctrl.Finish()
+}
+
+func TestCapture(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	var s string
+	ctrl.RecordCall(subject, "FooMethod", gomock.Capture(&s)).Times(2)
+	ctrl.Call(subject, "FooMethod", "a")
+	if s != "a" {
+		t.Errorf("captured %q, want a", s)
+	}
+	ctrl.Call(subject, "FooMethod", "b")
+	if s != "b" {
+		t.Errorf("captured %q, want b", s)
+	}
+
+	var n int
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", gomock.Any(), gomock.Capture(&n))
+	func() {
+		defer func() {
+			want := "gomock: Capture cannot store an argument of type string into a destination of type int"
+			if msg, _ := recover().(string); msg != want {
+				t.Errorf("Call panicked with %q, want %q", msg, want)
+			}
+		}()
+		ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{}, "not an int")
+	}()
+	ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{}, 3)
+	if n != 3 {
+		t.Errorf("captured %d, want 3", n)
+	}
+	ctrl.Finish()
+	reporter.assertPass("captured arguments")
+}
+
+func TestNameMock(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	primary := &NamedSubject{id: 1}
+	replica := &NamedSubject{id: 2}
+
+	ctrl.RecordCall(primary, "FooMethod", "1")
+	ctrl.NameMock(primary, "primaryDB")
+	ctrl.NameMock(replica, "replicaDB")
+	ctrl.RecordCall(replica, "BarMethod", "2")
+
+	reporter.assertFatal(func() {
+		ctrl.Call(replica, "FooMethod", "1")
+	}, "Unexpected call to replicaDB.FooMethod([1])")
+	reporter.assertFatal(ctrl.Finish, "aborting test due to missing call(s)")
+
+	var missing []string
+	for _, entry := range reporter.log {
+		if strings.HasPrefix(entry, "missing call(s) to ") {
+			missing = append(missing, entry)
+		}
+	}
+	if len(missing) != 2 {
+		t.Fatalf("expected 2 missing calls, got %v", missing)
+	}
+	for _, want := range []string{"primaryDB.FooMethod(is equal to 1)", "replicaDB.BarMethod(is equal to 2)"} {
+		if !strings.Contains(strings.Join(missing, "\n"), want) {
+			t.Errorf("missing calls %q do not mention %q", missing, want)
+		}
+	}
+}
+
+func TestExpectedMethods(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1")
+	ctrl.RecordCall(subject, "FooMethod", "2")
+	ctrl.RecordCall(subject, "BarMethod", "3")
+
+	assertEqual(t, []string{"BarMethod", "FooMethod"}, ctrl.ExpectedMethods(subject))
+
+	ctrl.Call(subject, "BarMethod", "3")
+	assertEqual(t, []string{"FooMethod"}, ctrl.ExpectedMethods(subject))
+
+	ctrl.Call(subject, "FooMethod", "1")
+	ctrl.Call(subject, "FooMethod", "2")
+	if methods := ctrl.ExpectedMethods(subject); len(methods) != 0 {
+		t.Errorf("ExpectedMethods() = %v, want none", methods)
+	}
+	ctrl.Finish()
+}
+
+func TestWithin(t *testing.T) {
+	t.Run("ReturnsInTime", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "FetchMethod", "1").Within(time.Minute).DoAndReturn(func(string) (int, error) {
+			return 1, io.EOF
+		})
+		rets := ctrl.Call(subject, "FetchMethod", "1")
+		if rets[0] != 1 || rets[1] != io.EOF {
+			t.Errorf("returned %v, want [1 EOF]", rets)
+		}
+		ctrl.Finish()
+		reporter.assertPass("action returning in time")
+	})
+
+	t.Run("TimesOut", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		release := make(chan struct{})
+		returned := make(chan struct{})
+		ctrl.RecordCall(subject, "FooMethod", "1").DoAndReturn(func(string) int {
+			defer close(returned)
+			<-release
+			return 1
+		}).Within(10 * time.Millisecond)
+		reporter.assertFatal(func() {
+			ctrl.Call(subject, "FooMethod", "1")
+		}, "action of call to *gomock_test.Subject.FooMethod did not return within 10ms")
+
+		// The blocked action can still return without blocking forever.
+		close(release)
+		select {
+		case <-returned:
+		case <-time.After(time.Minute):
+			t.Error("action did not return after being released")
+		}
+	})
+
+	t.Run("CancelsContext", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		returned := make(chan struct{})
+		ctrl.RecordCall(subject, "LoadMethod", gomock.Any(), "key").DoWithContext(func(ctx context.Context) {
+			defer close(returned)
+			<-ctx.Done()
+		}).Within(10 * time.Millisecond)
+		reporter.assertFatal(func() {
+			ctrl.Call(subject, "LoadMethod", context.Background(), "key")
+		}, "action of call to *gomock_test.Subject.LoadMethod did not return within 10ms")
+
+		// The action's goroutine is not left blocked.
+		select {
+		case <-returned:
+		case <-time.After(time.Minute):
+			t.Error("action waiting on its context did not return after the timeout")
+		}
+	})
+
+	t.Run("Panics", func(t *testing.T) {
+		_, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "FooMethod", "1").Within(time.Minute).Do(func(string) {
+			panic("boom")
+		})
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("recovered %v, want the panic of the action", r)
+			}
+		}()
+		ctrl.Call(subject, "FooMethod", "1")
+	})
+}
+
+func TestWithDoTimeBudget(t *testing.T) {
+	t.Run("WithinBudget", func(t *testing.T) {
+		reporter := NewErrorReporter(t)
+		ctrl := gomock.NewController(reporter, gomock.WithDoTimeBudget(time.Minute))
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "FooMethod", "1").Do(func(string) {})
+		ctrl.RecordCall(subject, "FetchMethod", "2").DoAndReturn(func(string) (int, error) { return 2, nil })
+		ctrl.Call(subject, "FooMethod", "1")
+		ctrl.Call(subject, "FetchMethod", "2")
+		ctrl.Finish()
+		reporter.assertPass("callbacks within budget")
+	})
+
+	t.Run("OverBudget", func(t *testing.T) {
+		reporter := NewErrorReporter(t)
+		ctrl := gomock.NewController(reporter, gomock.WithDoTimeBudget(20*time.Millisecond))
+		subject := new(Subject)
+
+		// Neither callback exceeds the budget by itself.
+		sleep := func(string) { time.Sleep(15 * time.Millisecond) }
+		ctrl.RecordCall(subject, "FooMethod", "1").Do(sleep)
+		ctrl.RecordCall(subject, "FetchMethod", "2").DoAndReturn(func(s string) (int, error) {
+			sleep(s)
+			return 2, nil
+		})
+		ctrl.Call(subject, "FooMethod", "1")
+		ctrl.Call(subject, "FetchMethod", "2")
+		ctrl.Finish()
+		reporter.assertFail("callbacks over budget")
+		if got := reporter.log[len(reporter.log)-1]; !strings.Contains(got, "exceeding the budget of 20ms") {
+			t.Errorf("failure = %q, want it to mention the budget", got)
+		}
+	})
+}
+
+func TestSatisfied(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	if !ctrl.Satisfied() {
+		t.Error("Satisfied() = false without expected calls, want true")
+	}
+	ctrl.RecordCall(subject, "FooMethod", "1").MinTimes(2)
+	ctrl.RecordCall(subject, "BarMethod", "2").AnyTimes()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ctrl.Call(subject, "FooMethod", "1")
+		ctrl.Call(subject, "FooMethod", "1")
+	}()
+	for !ctrl.Satisfied() {
+		time.Sleep(time.Millisecond)
+	}
+	<-done
+
+	ctrl.Call(subject, "FooMethod", "1")
+	if !ctrl.Satisfied() {
+		t.Error("Satisfied() = false after extra calls, want true")
+	}
+	ctrl.Finish()
+	reporter.assertPass("expectations satisfied")
+}
+
+func TestSatisfied_DoesNotFail(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1")
+	if ctrl.Satisfied() {
+		t.Error("Satisfied() = true with a missing call, want false")
+	}
+	if ctrl.Satisfied() {
+		t.Error("second Satisfied() = true with a missing call, want false")
+	}
+	reporter.assertPass("Satisfied with a missing call")
+
+	ctrl.Call(subject, "FooMethod", "1")
+	if !ctrl.Satisfied() {
+		t.Error("Satisfied() = false after the call, want true")
+	}
+	ctrl.Finish()
+	reporter.assertPass("expectations satisfied")
+}
+
+func TestSatisfied_AllOrNothing(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	gomock.AllOrNothing(
+		ctrl.RecordCall(subject, "FooMethod", "begin"),
+		ctrl.RecordCall(subject, "BarMethod", "commit"),
+	)
+	if !ctrl.Satisfied() {
+		t.Error("Satisfied() = false with an untouched AllOrNothing group, want true")
+	}
+
+	ctrl.Call(subject, "FooMethod", "begin")
+	if ctrl.Satisfied() {
+		t.Error("Satisfied() = true with a partially matched AllOrNothing group, want false")
+	}
+
+	ctrl.Call(subject, "BarMethod", "commit")
+	if !ctrl.Satisfied() {
+		t.Error("Satisfied() = false with a satisfied AllOrNothing group, want true")
+	}
+	ctrl.Finish()
+	reporter.assertPass("AllOrNothing group satisfied")
+}
+
+func TestDoWithContext(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	var got []interface{}
+	ctrl.RecordCall(subject, "LoadMethod", gomock.Any(), "key").DoWithContext(func(ctx context.Context) {
+		if ctx == nil {
+			got = append(got, nil)
+			return
+		}
+		got = append(got, ctx.Value(ctxKey("id")))
+	}).Return("value", nil).Times(2)
+
+	ctx := context.WithValue(context.Background(), ctxKey("id"), 7)
+	rets := ctrl.Call(subject, "LoadMethod", ctx, "key")
+	ctrl.Call(subject, "LoadMethod", nil, "key")
+	ctrl.Finish()
+	reporter.assertPass("calls with DoWithContext")
+
+	if want := []interface{}{7, nil}; !reflect.DeepEqual(got, want) {
+		t.Errorf("contexts passed to the function = %v, want %v", got, want)
+	}
+	if rets[0] != "value" {
+		t.Errorf("returned %v, want value", rets[0])
+	}
+}
+
+func TestDoWithContext_NoContextArgument(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FooMethod", "1").DoWithContext(func(context.Context) {})
+	}, "DoWithContext called for *gomock_test.Subject.FooMethod, whose first argument is not a context.Context")
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "CopyMethod", "a", "b").DoWithContext(func(context.Context) {})
+	}, "whose first argument is not a context.Context")
+}
+
+func TestDoAndReturn_ChannelArguments(t *testing.T) {
+	subject := new(Subject)
+
+	t.Run("Valid", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		var got []interface{}
+		ctrl.RecordCall(subject, "SubscribeMethod", gomock.Any()).DoAndReturn(func(ch chan<- string) {
+			got = append(got, ch)
+		})
+		ctrl.RecordCall(subject, "ReceiveMethod", gomock.Any()).DoAndReturn(func(ch <-chan string) {
+			got = append(got, ch)
+		})
+		ctrl.RecordCall(subject, "PipeMethod", gomock.Any()).DoAndReturn(func(ch chan<- string) chan string {
+			got = append(got, ch)
+			return make(chan string)
+		})
+		ctrl.RecordCall(subject, "PipeMethod", gomock.Any()).DoAndReturn(func(ch <-chan string) chan string {
+			got = append(got, ch)
+			return nil
+		})
+
+		ch := make(chan string)
+		ctrl.Call(subject, "SubscribeMethod", (chan<- string)(ch))
+		ctrl.Call(subject, "ReceiveMethod", (<-chan string)(ch))
+		ctrl.Call(subject, "PipeMethod", ch)
+		ctrl.Call(subject, "PipeMethod", ch)
+		ctrl.Finish()
+		reporter.assertPass("functions taking compatible channels")
+		if len(got) != 4 {
+			t.Errorf("functions were called %d times, want 4", len(got))
+		}
+	})
+
+	for _, tt := range []struct {
+		name, method string
+		f            interface{}
+		want         string
+	}{
+		{"SendOnly", "SubscribeMethod", func(chan string) {},
+			"chan<- string is not assignable to chan string (a directional channel cannot be used as a bidirectional one)"},
+		{"ReceiveOnly", "ReceiveMethod", func(chan<- string) {},
+			"<-chan string is not assignable to chan<- string (channel directions differ)"},
+		{"ElementType", "SubscribeMethod", func(chan<- int) {},
+			"chan<- string is not assignable to chan<- int (channel element type string differs from int)"},
+		{"Result", "PipeMethod", func(chan string) <-chan string { return nil },
+			"wrong type of return value 0 of function passed to DoAndReturn for *gomock_test.Subject.PipeMethod: " +
+				"<-chan string is not assignable to chan string (a directional channel cannot be used as a bidirectional one)"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter, ctrl := createFixtures(t)
+			reporter.assertFatal(func() {
+				ctrl.RecordCall(subject, tt.method, gomock.Any()).DoAndReturn(tt.f)
+			}, tt.want)
+		})
+	}
+}
+
+func TestDo_ChannelArguments(t *testing.T) {
+	subject := new(Subject)
+
+	t.Run("Valid", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		var got []interface{}
+		ctrl.RecordCall(subject, "SubscribeMethod", gomock.Any()).Do(func(ch chan<- string) {
+			got = append(got, ch)
+		})
+		// The results of functions passed to Do are ignored.
+		ctrl.RecordCall(subject, "PipeMethod", gomock.Any()).Do(func(ch <-chan string) {
+			got = append(got, ch)
+		})
+
+		ch := make(chan string)
+		ctrl.Call(subject, "SubscribeMethod", (chan<- string)(ch))
+		ctrl.Call(subject, "PipeMethod", ch)
+		ctrl.Finish()
+		reporter.assertPass("functions taking compatible channels")
+		if len(got) != 2 {
+			t.Errorf("functions were called %d times, want 2", len(got))
+		}
+	})
+
+	t.Run("SendOnly", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		reporter.assertFatal(func() {
+			ctrl.RecordCall(subject, "SubscribeMethod", gomock.Any()).Do(func(chan string) {})
+		}, "wrong type of argument 0 of function passed to Do for *gomock_test.Subject.SubscribeMethod: "+
+			"chan<- string is not assignable to chan string (a directional channel cannot be used as a bidirectional one)")
+	})
+
+	t.Run("WrongArity", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		reporter.assertFatal(func() {
+			ctrl.RecordCall(subject, "SubscribeMethod", gomock.Any()).Do(func() {})
+		}, "wrong signature of function passed to Do for *gomock_test.Subject.SubscribeMethod")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithDryRun())
+	subject := new(Subject)
+
+	first := ctrl.RecordCall(subject, "FooMethod", "1").Return("one")
+	second := ctrl.RecordCall(subject, "BarMethod", "2").Return(2)
+	gomock.InOrder(first, second)
+	first.After(second)
+	reporter.assertPass("setting up expectations in dry-run mode")
+
+	err := ctrl.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil, want an error")
+	}
+	for _, want := range []string{
+		"2 problem(s) with expected calls",
+		"wrong type of argument 0 to Return for *gomock_test.Subject.FooMethod: string is not assignable to int",
+		"Loop in call order",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %q, want it to contain %q", err, want)
+		}
+	}
+	if err := ctrl.Validate(); err != nil {
+		t.Errorf("second Validate() = %q, want nil", err)
+	}
+
+	ctrl.Call(subject, "FooMethod", "1")
+	ctrl.Call(subject, "BarMethod", "2")
+	ctrl.Finish()
+	reporter.assertPass("calls made after Validate")
+}
+
+func TestValidate_FinishReportsProblems(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithDryRun())
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1").Return(1, 2).AnyTimes()
+	reporter.assertPass("setting up expectations in dry-run mode")
+
+	ctrl.Finish()
+	reporter.assertFail("problem not returned by Validate")
+	if log := strings.Join(reporter.log, "\n"); !strings.Contains(log, "wrong number of arguments to Return") {
+		t.Errorf("log = %q, want it to report the wrong number of return values", log)
+	}
+}
+
+func TestValidate_CollectsArgumentProblems(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithDryRun())
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1").FailNThenSucceed(-1, nil, 1)
+	ctrl.RecordCall(subject, "FooMethod", "2").FailNThenSucceed(1, nil, 1)
+	ctrl.RecordCall(subject, "FooMethod", "3").ReturnSequence()
+	ctrl.RecordCall(subject, "FooMethod", "4").TimesRange(2, 1)
+	ctrl.RecordCall(subject, "FooMethod", "5").SetArg(0, "x")
+	ctrl.RecordCall(subject, "SetArgMethod", []byte{}, nil).SetArg(1, "x").SetArg(2, 1)
+	ctrl.RecordCall(subject, "FooMethod", "6").RequireArgWritten(0).RequireArgWritten(1)
+	ctrl.RecordCall(subject, "FooMethod", "7").AssertArgUnique(1)
+	reporter.assertPass("setting up expectations in dry-run mode")
+
+	err := ctrl.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil, want an error")
+	}
+	for _, want := range []string{
+		"10 problem(s) with expected calls",
+		"FailNThenSucceed(-1, ...) called with a negative number of failures",
+		"whose last return value is not an error",
+		"ReturnSequence called for *gomock_test.Subject.FooMethod without any return values",
+		"TimesRange(2, 1) called with an invalid range",
+		"SetArg(0, ...) referring to argument of non-pointer non-interface non-slice type string",
+		"SetArg(1, ...) argument is a string, not assignable to int",
+		"SetArg(2, ...) called for a method with 2 args",
+		"RequireArgWritten(0) referring to argument of non-pointer non-interface non-slice type string",
+		"RequireArgWritten(1) called for a method with 1 args",
+		"AssertArgUnique(1) called for a method with 1 args",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %q, want it to contain %q", err, want)
+		}
+	}
+}
+
+func TestValidate_WithoutDryRun(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FooMethod", "1").Return("one")
+	}, "wrong type of argument 0 to Return")
+	if err := ctrl.Validate(); err != nil {
+		t.Errorf("Validate() = %q, want nil", err)
+	}
+}
+
+func TestWaitForExpectations(t *testing.T) {
+	t.Run("AlreadySatisfied", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "FooMethod", "argument").AnyTimes()
+		if !ctrl.WaitForExpectations(time.Hour) {
+			t.Error("WaitForExpectations() = false, want true")
+		}
+		ctrl.Finish()
+		reporter.assertPass("expectations already satisfied")
+	})
+
+	t.Run("SatisfiedBeforeDeadline", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "FooMethod", "1")
+		ctrl.RecordCall(subject, "BarMethod", "2")
+		go func() {
+			ctrl.Call(subject, "FooMethod", "1")
+			time.Sleep(10 * time.Millisecond)
+			ctrl.Call(subject, "BarMethod", "2")
+		}()
+		if !ctrl.WaitForExpectations(time.Minute) {
+			t.Error("WaitForExpectations() = false, want true")
+		}
+		ctrl.Finish()
+		reporter.assertPass("expectations satisfied before deadline")
+	})
+
+	t.Run("DeadlineExceeded", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "FooMethod", "1")
+		ctrl.RecordCall(subject, "BarMethod", "2")
+		go ctrl.Call(subject, "FooMethod", "1")
+		if ctrl.WaitForExpectations(50 * time.Millisecond) {
+			t.Error("WaitForExpectations() = true, want false")
+		}
+		reporter.assertFail("expectations not satisfied before deadline")
+		if got := reporter.log[len(reporter.log)-1]; !strings.Contains(got, "missing call(s) to *gomock_test.Subject.BarMethod(is equal to 2)") {
+			t.Errorf("unexpected error message: %q", got)
+		}
+	})
+
+	t.Run("UntouchedAllOrNothingGroup", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		gomock.AllOrNothing(
+			ctrl.RecordCall(subject, "FooMethod", "begin"),
+			ctrl.RecordCall(subject, "BarMethod", "commit"),
+		)
+		if !ctrl.WaitForExpectations(time.Minute) {
+			t.Error("WaitForExpectations() = false, want true")
+		}
+		ctrl.Finish()
+		reporter.assertPass("untouched AllOrNothing group")
+	})
+
+	t.Run("PartialAllOrNothingGroup", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		gomock.AllOrNothing(
+			ctrl.RecordCall(subject, "FooMethod", "begin"),
+			ctrl.RecordCall(subject, "BarMethod", "commit"),
+		)
+		ctrl.Call(subject, "FooMethod", "begin")
+		if ctrl.WaitForExpectations(10 * time.Millisecond) {
+			t.Error("WaitForExpectations() = true, want false")
+		}
+		reporter.assertFail("AllOrNothing group only partially matched")
+		if got := reporter.log[len(reporter.log)-1]; !strings.Contains(got, "missing call(s) to *gomock_test.Subject.BarMethod(is equal to commit)") {
+			t.Errorf("unexpected error message: %q", got)
+		}
+	})
+}
+
+func TestCallWait(t *testing.T) {
+	t.Run("WorkerCalls", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+
+		call := ctrl.RecordCall(subject, "FooMethod", "1").Times(3)
+		go func() {
+			for i := 0; i < 3; i++ {
+				time.Sleep(time.Millisecond)
+				ctrl.Call(subject, "FooMethod", "1")
+			}
+		}()
+		if !call.Wait(3) {
+			t.Error("Wait() = false, want true")
+		}
+		ctrl.Finish()
+		reporter.assertPass("waited for the worker's calls")
+	})
+
+	t.Run("ContextDone", func(t *testing.T) {
+		reporter := NewErrorReporter(t)
+		ctrl, ctx := gomock.WithContext(context.Background(), reporter)
+		subject := new(Subject)
+
+		call := ctrl.RecordCall(subject, "FooMethod", "1")
+		go func() {
+			reporter.assertFatal(func() {
+				ctrl.Call(subject, "BarMethod", "unexpected")
+			})
+		}()
+		if call.Wait(1) {
+			t.Error("Wait() = true, want false")
+		}
+		if ctx.Err() == nil {
+			t.Error("context not done after fatal failure")
+		}
+	})
+}
+
+func TestNoHelper(t *testing.T) {
+	ctrlNoHelper := gomock.NewController(NewErrorReporter(t))
+
+	// doesn't panic
+	ctrlNoHelper.T.Helper()
+}
+
+func TestWithHelper(t *testing.T) {
+	withHelper := &HelperReporter{TestReporter: NewErrorReporter(t)}
+	ctrlWithHelper := gomock.NewController(withHelper)
+
+	ctrlWithHelper.T.Helper()
+
+	if withHelper.helper == 0 {
+		t.Fatal("expected Helper to be invoked")
+	}
+}
+
+func TestLazyExpect(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	runs := 0
+	ctrl.LazyExpect(func() {
+		runs++
+		ctrl.RecordCall(subject, "FooMethod", "1").Return(1)
+	})
+
+	rets := ctrl.Call(subject, "FooMethod", "1")
+	if len(rets) != 1 || rets[0] != 1 {
+		t.Errorf("Call() = %v, want [1]", rets)
+	}
+	if runs != 1 {
+		t.Errorf("lazy setup ran %d times, want 1", runs)
+	}
+
+	// The setup is not run again, so a second call is unexpected.
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "1")
+	}, "Unexpected call to")
+	if runs != 1 {
+		t.Errorf("lazy setup ran %d times, want 1", runs)
+	}
+	ctrl.Finish()
+}
+
+func TestLazyExpect_NotRunForMatchedCalls(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1")
+	ctrl.LazyExpect(func() {
+		t.Error("lazy setup ran although the call was expected")
+	})
+
+	ctrl.Call(subject, "FooMethod", "1")
+	ctrl.Finish()
+}
+
+func TestAssertArgs(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	argsEqual := func(args []interface{}) error {
+		if args[0] != args[1] {
+			return fmt.Errorf("dst %v != src %v", args[0], args[1])
+		}
+		return nil
+	}
+	ctrl.RecordCall(subject, "CopyMethod", gomock.Any(), gomock.Any()).AssertArgs(argsEqual)
+
+	ctrl.Call(subject, "CopyMethod", "a", "a")
+	reporter.assertPass("matching args")
+
+	ctrl.RecordCall(subject, "CopyMethod", gomock.Any(), gomock.Any()).AssertArgs(argsEqual)
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "CopyMethod", "a", "b")
+	}, "Unexpected call to", "doesn't satisfy the argument assertion", "dst a != src b")
+}
+
+func TestForbid(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	sameArgs := func(args []interface{}) bool { return args[0] == args[1] }
+	ctrl.RecordCall(subject, "CopyMethod", gomock.Any(), gomock.Any()).Forbid(sameArgs)
+
+	ctrl.Call(subject, "CopyMethod", "a", "b")
+	reporter.assertPass("allowed combination")
+
+	ctrl.RecordCall(subject, "CopyMethod", gomock.Any(), gomock.Any()).Forbid(sameArgs)
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "CopyMethod", "a", "a")
+	}, "Unexpected call to", "doesn't satisfy the argument assertion", "forbidden combination")
+}
+
+func TestAssertArgs_NotEvaluatedWhenMatcherFails(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "CopyMethod", "a", gomock.Any()).AssertArgs(func(args []interface{}) error {
+		t.Error("assertion evaluated although an argument did not match")
+		return nil
+	})
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "CopyMethod", "b", "b")
+	}, "doesn't match the argument at index 0")
+}
+
+func TestFromSameGoroutine(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1").FromSameGoroutine().AnyTimes()
+	ctrl.Call(subject, "FooMethod", "1")
+	reporter.assertPass("call from the same goroutine")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		reporter.assertFatal(func() {
+			ctrl.Call(subject, "FooMethod", "1")
+		}, "Unexpected call to", "must be made from goroutine", "which set it up")
+	}()
+	<-done
+}
+
+func TestFromDifferentGoroutine(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1").FromDifferentGoroutine().AnyTimes()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ctrl.Call(subject, "FooMethod", "1")
+	}()
+	<-done
+	reporter.assertPass("call from a different goroutine")
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "1")
+	}, "Unexpected call to", "which set it up, but must be made from another goroutine")
+}
+
+func TestOnSatisfied(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	satisfied := make(chan struct{}, 2)
+	ctrl.RecordCall(subject, "FooMethod", "1").Times(2).OnSatisfied(func() {
+		satisfied <- struct{}{}
+	})
+
+	ctrl.Call(subject, "FooMethod", "1")
+	select {
+	case <-satisfied:
+		t.Fatal("OnSatisfied called after the first of two calls")
+	default:
+	}
+
+	go ctrl.Call(subject, "FooMethod", "1")
+	select {
+	case <-satisfied:
+	case <-time.After(time.Second):
+		t.Fatal("OnSatisfied not called after the second of two calls")
+	}
+	ctrl.Finish()
+}
+
+func TestOnSatisfied_CalledOnce(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	calls := 0
+	ctrl.RecordCall(subject, "FooMethod", "1").MinTimes(1).OnSatisfied(func() { calls++ })
+	ctrl.RecordCall(subject, "BarMethod", "1").AnyTimes().OnSatisfied(func() {
+		t.Error("OnSatisfied called for AnyTimes")
+	})
+
+	for i := 0; i < 3; i++ {
+		ctrl.Call(subject, "FooMethod", "1")
+		ctrl.Call(subject, "BarMethod", "1")
+	}
+	if calls != 1 {
+		t.Errorf("OnSatisfied called %d times, want 1", calls)
+	}
+	ctrl.Finish()
+}
+
+func TestCountSnapshot(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1").AnyTimes()
+	ctrl.RecordCall(subject, "BarMethod", "1").AnyTimes()
+
+	ctrl.Call(subject, "FooMethod", "1")
+	before := ctrl.CountSnapshot()
+	assertEqual(t, map[string]int{"FooMethod": 1}, before)
+
+	// Phase two.
+	ctrl.Call(subject, "FooMethod", "1")
+	ctrl.Call(subject, "FooMethod", "1")
+	ctrl.Call(subject, "BarMethod", "1")
+	after := ctrl.CountSnapshot()
+
+	assertEqual(t, map[string]int{"FooMethod": 3, "BarMethod": 1}, after)
+	assertEqual(t, map[string]int{"FooMethod": 2, "BarMethod": 1}, gomock.CountDelta(before, after))
+	assertEqual(t, map[string]int{}, gomock.CountDelta(after, ctrl.CountSnapshot()))
+
+	// Snapshots are copies.
+	before["FooMethod"] = 100
+	assertEqual(t, 3, ctrl.CountSnapshot()["FooMethod"])
+	ctrl.Finish()
 }
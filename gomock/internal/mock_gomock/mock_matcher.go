@@ -11,7 +11,7 @@ import (
 
 // MockMatcher is a mock of Matcher interface
 type MockMatcher struct {
-	ctrl     *gomock.Controller
+	ctrl     gomock.ControllerInterface
 	recorder *MockMatcherMockRecorder
 }
 
@@ -20,8 +20,11 @@ type MockMatcherMockRecorder struct {
 	mock *MockMatcher
 }
 
+// Verify that the mock satisfies the interface at compile time.
+var _ gomock.Matcher = (*MockMatcher)(nil)
+
 // NewMockMatcher creates a new mock instance
-func NewMockMatcher(ctrl *gomock.Controller) *MockMatcher {
+func NewMockMatcher(ctrl gomock.ControllerInterface) *MockMatcher {
 	mock := &MockMatcher{ctrl: ctrl}
 	mock.recorder = &MockMatcherMockRecorder{mock}
 	return mock
@@ -34,7 +37,7 @@ func (m *MockMatcher) EXPECT() *MockMatcherMockRecorder {
 
 // Matches mocks base method
 func (m *MockMatcher) Matches(arg0 interface{}) bool {
-	m.ctrl.T.Helper()
+	m.ctrl.TestHelper().Helper()
 	ret := m.ctrl.Call(m, "Matches", arg0)
 	ret0, _ := ret[0].(bool)
 	return ret0
@@ -42,13 +45,13 @@ func (m *MockMatcher) Matches(arg0 interface{}) bool {
 
 // Matches indicates an expected call of Matches
 func (mr *MockMatcherMockRecorder) Matches(arg0 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
+	mr.mock.ctrl.TestHelper().Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Matches", reflect.TypeOf((*MockMatcher)(nil).Matches), arg0)
 }
 
 // String mocks base method
 func (m *MockMatcher) String() string {
-	m.ctrl.T.Helper()
+	m.ctrl.TestHelper().Helper()
 	ret := m.ctrl.Call(m, "String")
 	ret0, _ := ret[0].(string)
 	return ret0
@@ -56,6 +59,6 @@ func (m *MockMatcher) String() string {
 
 // String indicates an expected call of String
 func (mr *MockMatcherMockRecorder) String() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
+	mr.mock.ctrl.TestHelper().Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "String", reflect.TypeOf((*MockMatcher)(nil).String))
 }
@@ -15,9 +15,18 @@
 package gomock
 
 import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 )
 
 // A Matcher is a representation of a class of values.
@@ -97,16 +106,312 @@ func (anyMatcher) String() string {
 	return "is anything"
 }
 
+// Got renders a value matched by Any along with its type, since nothing
+// else in a failure message describes it.
+func (anyMatcher) Got(got interface{}) string {
+	return fmt.Sprintf("%s (%T)", formatValue(got), got)
+}
+
+// formatValue formats x like %v for use in failure messages, except that
+// durations and times, and non-nil pointers to them, are shown using their
+// String methods. The monotonic clock reading of a time is left out, since it
+// never takes part in equality.
+func formatValue(x interface{}) string {
+	switch v := x.(type) {
+	case time.Duration:
+		return v.String()
+	case *time.Duration:
+		if v != nil {
+			return "&" + v.String()
+		}
+	case time.Time:
+		return v.Round(0).String()
+	case *time.Time:
+		if v != nil {
+			return "&" + v.Round(0).String()
+		}
+	}
+	return fmt.Sprintf("%v", x)
+}
+
 type eqMatcher struct {
 	x interface{}
 }
 
 func (e eqMatcher) Matches(x interface{}) bool {
+	// Values of the same basic type are deeply equal if and only if they are
+	// equal, and comparing them directly is much cheaper.
+	if t := reflect.TypeOf(e.x); t != nil && t == reflect.TypeOf(x) {
+		switch t.Kind() {
+		case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+			reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128, reflect.String:
+			return e.x == x
+		}
+	}
 	return reflect.DeepEqual(e.x, x)
 }
 
 func (e eqMatcher) String() string {
-	return fmt.Sprintf("is equal to %v", e.x)
+	return "is equal to " + formatValue(e.x)
+}
+
+type sameMatcher struct {
+	x reflect.Value
+}
+
+func (m sameMatcher) Matches(x interface{}) bool {
+	v := reflect.ValueOf(x)
+	if !v.IsValid() || v.Kind() != m.x.Kind() {
+		return false
+	}
+	// Channels of different directions refer to the same channel when they
+	// are converted from one another.
+	if v.Kind() == reflect.Chan {
+		if v.Type().Elem() != m.x.Type().Elem() {
+			return false
+		}
+	} else if v.Type() != m.x.Type() {
+		return false
+	}
+	return v.Pointer() == m.x.Pointer()
+}
+
+func (m sameMatcher) String() string {
+	return fmt.Sprintf("is the same %v as %v", m.x.Type(), m.x)
+}
+
+type eqExportedMatcher struct {
+	x interface{}
+}
+
+func (m eqExportedMatcher) Matches(x interface{}) bool {
+	return exportedEqual(reflect.ValueOf(m.x), reflect.ValueOf(x), make(map[[2]uintptr]bool))
+}
+
+func (m eqExportedMatcher) String() string {
+	return fmt.Sprintf("has exported fields equal to those of %v", m.x)
+}
+
+// exportedEqual reports whether a and b are deeply equal, as by
+// reflect.DeepEqual, ignoring the unexported fields of structs. visited holds
+// the pairs of pointers being compared, so that cyclic values terminate.
+func exportedEqual(a, b reflect.Value, visited map[[2]uintptr]bool) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		if a.Kind() != reflect.Slice || a.Len() > 0 {
+			pair := [2]uintptr{a.Pointer(), b.Pointer()}
+			if visited[pair] {
+				return true
+			}
+			visited[pair] = true
+		}
+	}
+
+	switch a.Kind() {
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if a.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			if !exportedEqual(a.Field(i), b.Field(i), visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Ptr, reflect.Interface:
+		return exportedEqual(a.Elem(), b.Elem(), visited)
+	case reflect.Array, reflect.Slice:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !exportedEqual(a.Index(i), b.Index(i), visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for _, k := range a.MapKeys() {
+			bv := b.MapIndex(k)
+			if !bv.IsValid() || !exportedEqual(a.MapIndex(k), bv, visited) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+}
+
+type sliceEqMatcher struct {
+	expected       interface{}
+	nilEqualsEmpty bool
+}
+
+func (m sliceEqMatcher) Matches(x interface{}) bool {
+	if reflect.TypeOf(x) != reflect.TypeOf(m.expected) {
+		return false
+	}
+	ev, xv := reflect.ValueOf(m.expected), reflect.ValueOf(x)
+	if ev.Len() != xv.Len() {
+		return false
+	}
+	if !m.nilEqualsEmpty && ev.IsNil() != xv.IsNil() {
+		return false
+	}
+	// Like reflect.DeepEqual, consider slices sharing their elements equal
+	// even if those are NaNs.
+	if ev.Pointer() == xv.Pointer() {
+		return true
+	}
+
+	// Compare the most common slice types without reflection.
+	switch e := m.expected.(type) {
+	case []byte:
+		return bytes.Equal(e, x.([]byte))
+	case []int:
+		y := x.([]int)
+		for i := range e {
+			if e[i] != y[i] {
+				return false
+			}
+		}
+		return true
+	case []int64:
+		y := x.([]int64)
+		for i := range e {
+			if e[i] != y[i] {
+				return false
+			}
+		}
+		return true
+	case []float64:
+		y := x.([]float64)
+		for i := range e {
+			if e[i] != y[i] {
+				return false
+			}
+		}
+		return true
+	case []string:
+		y := x.([]string)
+		for i := range e {
+			if e[i] != y[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	var equal func(i int) bool
+	switch ev.Type().Elem().Kind() {
+	case reflect.Bool:
+		equal = func(i int) bool { return ev.Index(i).Bool() == xv.Index(i).Bool() }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		equal = func(i int) bool { return ev.Index(i).Int() == xv.Index(i).Int() }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		equal = func(i int) bool { return ev.Index(i).Uint() == xv.Index(i).Uint() }
+	case reflect.Float32, reflect.Float64:
+		equal = func(i int) bool { return ev.Index(i).Float() == xv.Index(i).Float() }
+	case reflect.Complex64, reflect.Complex128:
+		equal = func(i int) bool { return ev.Index(i).Complex() == xv.Index(i).Complex() }
+	case reflect.String:
+		equal = func(i int) bool { return ev.Index(i).String() == xv.Index(i).String() }
+	default:
+		equal = func(i int) bool { return reflect.DeepEqual(ev.Index(i).Interface(), xv.Index(i).Interface()) }
+	}
+	for i := 0; i < ev.Len(); i++ {
+		if !equal(i) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m sliceEqMatcher) String() string {
+	if m.nilEqualsEmpty {
+		return fmt.Sprintf("is equal to %v, treating nil as empty", m.expected)
+	}
+	return fmt.Sprintf("is equal to %v", m.expected)
+}
+
+type eqFoldMatcher struct {
+	s string
+}
+
+func (e eqFoldMatcher) Matches(x interface{}) bool {
+	switch v := x.(type) {
+	case string:
+		return strings.EqualFold(e.s, v)
+	case fmt.Stringer:
+		return strings.EqualFold(e.s, v.String())
+	}
+	return false
+}
+
+func (e eqFoldMatcher) String() string {
+	return fmt.Sprintf("equals (case-insensitive) %q", e.s)
+}
+
+type regexpMatcher struct {
+	re *regexp.Regexp
+}
+
+func (r regexpMatcher) Matches(x interface{}) bool {
+	switch v := x.(type) {
+	case string:
+		return r.re.MatchString(v)
+	case []byte:
+		return r.re.Match(v)
+	case fmt.Stringer:
+		return r.re.MatchString(v.String())
+	}
+	return false
+}
+
+func (r regexpMatcher) String() string {
+	return fmt.Sprintf("matches regexp %q", r.re)
+}
+
+type regexCaptureMatcher struct {
+	re    *regexp.Regexp
+	group int
+	m     Matcher
+}
+
+func (r regexCaptureMatcher) Matches(x interface{}) bool {
+	var s string
+	switch v := x.(type) {
+	case string:
+		s = v
+	case fmt.Stringer:
+		s = v.String()
+	default:
+		return false
+	}
+	groups := r.re.FindStringSubmatch(s)
+	if groups == nil {
+		return false
+	}
+	return r.m.Matches(groups[r.group])
+}
+
+func (r regexCaptureMatcher) String() string {
+	return fmt.Sprintf("matches %q with group %d that %s", r.re, r.group, r.m)
 }
 
 type nilMatcher struct{}
@@ -155,6 +460,18 @@ func (m assignableToTypeOfMatcher) String() string {
 	return "is assignable to " + m.targetType.Name()
 }
 
+type implementsMatcher struct {
+	iface reflect.Type
+}
+
+func (m implementsMatcher) Matches(x interface{}) bool {
+	return x != nil && reflect.TypeOf(x).Implements(m.iface)
+}
+
+func (m implementsMatcher) String() string {
+	return "implements " + m.iface.String()
+}
+
 type allMatcher struct {
 	matchers []Matcher
 }
@@ -176,6 +493,95 @@ func (am allMatcher) String() string {
 	return strings.Join(ss, "; ")
 }
 
+type anyOfMatcher struct {
+	matchers []Matcher
+	dest     *int // may be nil
+}
+
+func (am anyOfMatcher) Matches(x interface{}) bool {
+	for i, m := range am.matchers {
+		if m.Matches(x) {
+			if am.dest != nil {
+				*am.dest = i
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func (am anyOfMatcher) String() string {
+	ss := make([]string, 0, len(am.matchers))
+	for _, matcher := range am.matchers {
+		ss = append(ss, matcher.String())
+	}
+	return strings.Join(ss, " | ")
+}
+
+// oneOfMatcher is an anyOfMatcher that describes itself as a list of values.
+type oneOfMatcher struct {
+	anyOfMatcher
+}
+
+func (om oneOfMatcher) String() string {
+	if len(om.matchers) == 0 {
+		return "is one of no values (never matches)"
+	}
+	ss := make([]string, 0, len(om.matchers))
+	for _, m := range om.matchers {
+		ss = append(ss, m.String())
+	}
+	return "is one of: " + strings.Join(ss, "; ")
+}
+
+type inAnyOrderMatcher struct {
+	x        interface{}
+	matchers []Matcher
+}
+
+func (m inAnyOrderMatcher) Matches(x interface{}) bool {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Array && v.Kind() != reflect.Slice || v.Len() != len(m.matchers) {
+		return false
+	}
+
+	// Look for a one-to-one assignment of values to matchers, by finding an
+	// augmenting path for each value in turn. Assigning values greedily is not
+	// enough when a value matches several matchers, such as Any().
+	values := make([]interface{}, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Interface()
+	}
+	assigned := make([]int, len(m.matchers)) // the value assigned to each matcher
+	for i := range assigned {
+		assigned[i] = -1
+	}
+	var assign func(value int, tried []bool) bool
+	assign = func(value int, tried []bool) bool {
+		for i, matcher := range m.matchers {
+			if tried[i] || !matcher.Matches(values[value]) {
+				continue
+			}
+			tried[i] = true
+			if assigned[i] < 0 || assign(assigned[i], tried) {
+				assigned[i] = value
+				return true
+			}
+		}
+		return false
+	}
+	for value := range values {
+		if !assign(value, make([]bool, len(m.matchers))) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m inAnyOrderMatcher) String() string {
+	return fmt.Sprintf("has the same elements as %v, in any order", m.x)
+}
+
 type lenMatcher struct {
 	i int
 }
@@ -194,47 +600,1335 @@ func (m lenMatcher) String() string {
 	return fmt.Sprintf("has length %d", m.i)
 }
 
-// Constructors
+type lenMatchesMatcher struct {
+	m Matcher
+}
 
-// All returns a composite Matcher that returns true if and only all of the
-// matchers return true.
-func All(ms ...Matcher) Matcher { return allMatcher{ms} }
+func (m lenMatchesMatcher) Matches(x interface{}) bool {
+	v := reflect.ValueOf(x)
+	switch v.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+		return m.m.Matches(v.Len())
+	default:
+		return false
+	}
+}
 
-// Any returns a matcher that always matches.
-func Any() Matcher { return anyMatcher{} }
+func (m lenMatchesMatcher) String() string {
+	return "has length " + m.m.String()
+}
 
-// Eq returns a matcher that matches on equality.
-//
-// Example usage:
-//   Eq(5).Matches(5) // returns true
-//   Eq(5).Matches(4) // returns false
-func Eq(x interface{}) Matcher { return eqMatcher{x} }
+type emptyMatcher struct {
+	empty bool
+}
 
-// Len returns a matcher that matches on length. This matcher returns false if
-// is compared to a type that is not an array, chan, map, slice, or string.
-func Len(i int) Matcher {
-	return lenMatcher{i}
+func (m emptyMatcher) Matches(x interface{}) bool {
+	if x == nil {
+		return m.empty
+	}
+
+	v := reflect.ValueOf(x)
+	switch v.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+		return (v.Len() == 0) == m.empty
+	default:
+		return false
+	}
 }
 
-// Nil returns a matcher that matches if the received value is nil.
-//
-// Example usage:
-//   var x *bytes.Buffer
-//   Nil().Matches(x) // returns true
-//   x = &bytes.Buffer{}
-//   Nil().Matches(x) // returns false
-func Nil() Matcher { return nilMatcher{} }
+func (m emptyMatcher) String() string {
+	if m.empty {
+		return "is empty"
+	}
+	return "is not empty"
+}
 
-// Not reverses the results of its given child matcher.
-//
-// Example usage:
-//   Not(Eq(5)).Matches(4) // returns true
-//   Not(Eq(5)).Matches(5) // returns false
-func Not(x interface{}) Matcher {
-	if m, ok := x.(Matcher); ok {
-		return notMatcher{m}
+type nonNilPtrMatcher struct {
+	m Matcher
+}
+
+func (n nonNilPtrMatcher) Matches(x interface{}) bool {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return false
 	}
-	return notMatcher{Eq(x)}
+	return n.m.Matches(v.Elem().Interface())
+}
+
+func (n nonNilPtrMatcher) String() string {
+	return "non-nil pointer to " + n.m.String()
+}
+
+type eqWithMatcher struct {
+	expected interface{}
+	eq       func(a, b interface{}) bool
+}
+
+func (m eqWithMatcher) Matches(x interface{}) bool {
+	return m.eq(m.expected, x)
+}
+
+func (m eqWithMatcher) String() string {
+	return fmt.Sprintf("is equal to %v by a custom comparison", m.expected)
+}
+
+type mapKeysMatcher struct {
+	keys []interface{}
+}
+
+func (m mapKeysMatcher) Matches(x interface{}) bool {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Map || v.Len() != len(m.keys) {
+		return false
+	}
+	kt := v.Type().Key()
+	for _, key := range m.keys {
+		k := reflect.ValueOf(key)
+		if !k.IsValid() || !k.Type().AssignableTo(kt) {
+			return false
+		}
+		if !v.MapIndex(k).IsValid() {
+			return false
+		}
+	}
+	return true
+}
+
+func (m mapKeysMatcher) String() string {
+	return fmt.Sprintf("has exactly keys %v", m.keys)
+}
+
+type containsMatcher struct {
+	x interface{}
+}
+
+func (m containsMatcher) Matches(x interface{}) bool {
+	v := reflect.ValueOf(x)
+	switch v.Kind() {
+	case reflect.String:
+		sub := reflect.ValueOf(m.x)
+		return sub.Kind() == reflect.String && strings.Contains(v.String(), sub.String())
+	case reflect.Array, reflect.Slice:
+		em := m.elemMatcher()
+		for i := 0; i < v.Len(); i++ {
+			if em.Matches(v.Index(i).Interface()) {
+				return true
+			}
+		}
+		return false
+	case reflect.Map:
+		em := m.elemMatcher()
+		for _, k := range v.MapKeys() {
+			if em.Matches(k.Interface()) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// elemMatcher returns the matcher applied to slice elements and map keys.
+func (m containsMatcher) elemMatcher() Matcher {
+	if em, ok := m.x.(Matcher); ok {
+		return em
+	}
+	return Eq(m.x)
+}
+
+func (m containsMatcher) String() string {
+	if sub := reflect.ValueOf(m.x); sub.Kind() == reflect.String {
+		return fmt.Sprintf("contains substring %q, or an element or key equal to it", sub.String())
+	}
+	if em, ok := m.x.(Matcher); ok {
+		return "contains an element or key that " + em.String()
+	}
+	return fmt.Sprintf("contains an element or key equal to %v", m.x)
+}
+
+type containsSubsequenceMatcher struct {
+	elems []Matcher
+}
+
+func (m containsSubsequenceMatcher) Matches(x interface{}) bool {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Array && v.Kind() != reflect.Slice {
+		return false
+	}
+	// Matching each element as early as possible leaves the most room for
+	// the rest of the subsequence.
+	next := 0
+	for i := 0; i < v.Len() && next < len(m.elems); i++ {
+		if m.elems[next].Matches(v.Index(i).Interface()) {
+			next++
+		}
+	}
+	return next == len(m.elems)
+}
+
+func (m containsSubsequenceMatcher) String() string {
+	ss := make([]string, len(m.elems))
+	for i, em := range m.elems {
+		ss[i] = em.String()
+	}
+	return fmt.Sprintf("contains a subsequence of elements that: %s", strings.Join(ss, "; "))
+}
+
+type marshalsToMatcher struct {
+	expected string      // the expected JSON, as given
+	want     interface{} // the expected JSON, unmarshaled
+}
+
+func (m marshalsToMatcher) Matches(x interface{}) bool {
+	b, err := json.Marshal(x)
+	if err != nil {
+		return false
+	}
+	var got interface{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(m.want, got)
+}
+
+func (m marshalsToMatcher) String() string {
+	return "marshals to JSON " + m.expected
+}
+
+type jsonEqMatcher struct {
+	expected  interface{} // the expected JSON, unmarshaled
+	canonical string      // the expected JSON, marshaled again
+}
+
+func (m jsonEqMatcher) Matches(x interface{}) bool {
+	var data []byte
+	v := reflect.ValueOf(x)
+	switch {
+	case v.Kind() == reflect.String:
+		data = []byte(v.String())
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		data = v.Bytes()
+	default:
+		return false
+	}
+	var got interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(m.expected, got)
+}
+
+func (m jsonEqMatcher) String() string {
+	return "is JSON equal to " + m.canonical
+}
+
+type gobRoundTripsMatcher struct{}
+
+func (gobRoundTripsMatcher) Matches(x interface{}) bool {
+	// encoding/gob recurses without limit on cyclic values.
+	if x == nil || hasCycle(reflect.ValueOf(x), make(map[visit]bool), make(map[visit]bool)) {
+		return false
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(x); err != nil {
+		return false
+	}
+	got := reflect.New(reflect.TypeOf(x))
+	if err := gob.NewDecoder(&buf).DecodeValue(got); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, got.Elem().Interface())
+}
+
+func (gobRoundTripsMatcher) String() string {
+	return "round-trips through encoding/gob"
+}
+
+// A visit is a pointer, map or slice walked by hasCycle. Its type tells apart
+// a struct and its first field, which share an address, and its length slices
+// sharing their first element.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// hasCycle reports whether v refers back to itself through pointers, maps,
+// slices or interfaces. onPath holds the visits on the path to v, and done
+// those already walked without finding a cycle, so that values shared many
+// times are walked once.
+func hasCycle(v reflect.Value, onPath, done map[visit]bool) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return false
+		}
+		key := visit{v.Pointer(), v.Type(), 0}
+		if v.Kind() == reflect.Slice {
+			key.len = v.Len()
+		}
+		if onPath[key] {
+			return true
+		}
+		if done[key] {
+			return false
+		}
+		onPath[key] = true
+		defer func() {
+			delete(onPath, key)
+			done[key] = true
+		}()
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return hasCycle(v.Elem(), onPath, done)
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if hasCycle(v.Index(i), onPath, done) {
+				return true
+			}
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			if hasCycle(k, onPath, done) || hasCycle(v.MapIndex(k), onPath, done) {
+				return true
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if hasCycle(v.Field(i), onPath, done) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type contextValueMatcher struct {
+	key interface{}
+	m   Matcher
+}
+
+func (m contextValueMatcher) Matches(x interface{}) bool {
+	ctx, ok := x.(context.Context)
+	if !ok || ctx == nil {
+		return false
+	}
+	v := ctx.Value(m.key)
+	return v != nil && m.m.Matches(v)
+}
+
+func (m contextValueMatcher) String() string {
+	return fmt.Sprintf("is a context whose value for key %v %s", m.key, m.m)
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+type sliceInDeltaMatcher struct {
+	expected []float64
+	delta    float64
+}
+
+func (m sliceInDeltaMatcher) Matches(x interface{}) bool {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Slice || v.Len() != len(m.expected) {
+		return false
+	}
+	if k := v.Type().Elem().Kind(); k != reflect.Float32 && k != reflect.Float64 {
+		return false
+	}
+	for i, want := range m.expected {
+		// Written so that NaNs are never within delta.
+		if !(math.Abs(v.Index(i).Float()-want) <= m.delta) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m sliceInDeltaMatcher) String() string {
+	return fmt.Sprintf("is within %v of %v", m.delta, m.expected)
+}
+
+type approxEqMatcher struct {
+	value, tolerance float64
+}
+
+func (m approxEqMatcher) Matches(x interface{}) bool {
+	v := reflect.ValueOf(x)
+	if k := v.Kind(); k != reflect.Float32 && k != reflect.Float64 {
+		return false
+	}
+	f := v.Float()
+	if math.IsInf(m.value, 0) {
+		// An infinity is only close to itself, whatever the tolerance.
+		return f == m.value
+	}
+	// Written so that NaNs are never within tolerance.
+	return math.Abs(f-m.value) <= m.tolerance
+}
+
+func (m approxEqMatcher) String() string {
+	return fmt.Sprintf("is within %v of %v", m.tolerance, m.value)
+}
+
+type bigEqMatcher struct {
+	expected reflect.Value
+	cmp      reflect.Value // the Cmp method of expected
+}
+
+func (m bigEqMatcher) Matches(x interface{}) bool {
+	v := reflect.ValueOf(x)
+	if !v.IsValid() || v.Type() != m.expected.Type() {
+		return false
+	}
+	// Cmp panics on nil pointers, which are only equal to one another.
+	if m.expected.IsNil() || v.IsNil() {
+		return m.expected.IsNil() && v.IsNil()
+	}
+	return m.cmp.Call([]reflect.Value{v})[0].Int() == 0
+}
+
+func (m bigEqMatcher) String() string {
+	return fmt.Sprintf("is numerically equal to %v", m.expected)
+}
+
+type betweenMatcher struct {
+	low, high interface{}
+}
+
+func (m betweenMatcher) Matches(x interface{}) bool {
+	v := reflect.ValueOf(x)
+	lowCmp, ok := compareNumbers(reflect.ValueOf(m.low), v)
+	if !ok || lowCmp > 0 {
+		return false
+	}
+	highCmp, ok := compareNumbers(v, reflect.ValueOf(m.high))
+	return ok && highCmp <= 0
+}
+
+func (m betweenMatcher) String() string {
+	return fmt.Sprintf("in [%v, %v]", m.low, m.high)
+}
+
+type compareMatcher struct {
+	bound interface{}
+	name  string           // such as "greater than"
+	ok    func(c int) bool // reports whether the result of comparing x to bound is allowed
+}
+
+func (m compareMatcher) Matches(x interface{}) bool {
+	c, ok := compareNumbers(reflect.ValueOf(x), reflect.ValueOf(m.bound))
+	return ok && m.ok(c)
+}
+
+func (m compareMatcher) String() string {
+	return fmt.Sprintf("%s %v", m.name, m.bound)
+}
+
+// compareNumbers returns -1, 0 or 1 as a is less than, equal to or greater
+// than b, where a and b are of any integer or floating-point kinds. It
+// reports false if either is not a number or is NaN.
+func compareNumbers(a, b reflect.Value) (int, bool) {
+	ak, bk := numberKind(a), numberKind(b)
+	switch {
+	case ak == reflect.Invalid || bk == reflect.Invalid:
+		return 0, false
+	case ak == reflect.Float64 || bk == reflect.Float64:
+		x, y := numberAsFloat(a), numberAsFloat(b)
+		if math.IsNaN(x) || math.IsNaN(y) {
+			return 0, false
+		}
+		return compareOrdered(x < y, x > y), true
+	case ak == reflect.Int64 && bk == reflect.Int64:
+		x, y := a.Int(), b.Int()
+		return compareOrdered(x < y, x > y), true
+	case ak == reflect.Uint64 && bk == reflect.Uint64:
+		x, y := a.Uint(), b.Uint()
+		return compareOrdered(x < y, x > y), true
+	case ak == reflect.Int64: // and b is unsigned
+		if a.Int() < 0 {
+			return -1, true
+		}
+		x, y := uint64(a.Int()), b.Uint()
+		return compareOrdered(x < y, x > y), true
+	default: // a is unsigned and b is signed
+		if b.Int() < 0 {
+			return 1, true
+		}
+		x, y := a.Uint(), uint64(b.Int())
+		return compareOrdered(x < y, x > y), true
+	}
+}
+
+func compareOrdered(less, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	}
+	return 0
+}
+
+// numberKind returns Int64, Uint64 or Float64 for values of the signed
+// integer, unsigned integer and floating-point kinds, and Invalid otherwise.
+func numberKind(v reflect.Value) reflect.Kind {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int64
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.Uint64
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	}
+	return reflect.Invalid
+}
+
+func numberAsFloat(v reflect.Value) float64 {
+	switch numberKind(v) {
+	case reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint64:
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
+
+type fieldByTagMatcher struct {
+	key, value string
+	m          Matcher
+}
+
+func (f fieldByTagMatcher) Matches(x interface{}) bool {
+	v := reflect.ValueOf(x)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < v.NumField(); i++ {
+		tag, ok := v.Type().Field(i).Tag.Lookup(f.key)
+		if !ok || !v.Field(i).CanInterface() {
+			continue
+		}
+		// Options such as omitempty follow the name after a comma.
+		if tag == f.value || strings.SplitN(tag, ",", 2)[0] == f.value {
+			return f.m.Matches(v.Field(i).Interface())
+		}
+	}
+	return false
+}
+
+func (f fieldByTagMatcher) String() string {
+	return fmt.Sprintf("has field tagged %s:%q that %s", f.key, f.value, f.m)
+}
+
+type eqFieldMatcher struct {
+	eqMatcher
+	field      string
+	structType reflect.Type
+}
+
+func (e eqFieldMatcher) String() string {
+	return fmt.Sprintf("%s (field %s of %v)", e.eqMatcher, e.field, e.structType)
+}
+
+type fieldsMatcher struct {
+	fields map[string]Matcher
+}
+
+func (f fieldsMatcher) Matches(x interface{}) bool {
+	v := reflect.ValueOf(x)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	for name, m := range f.fields {
+		sf, ok := v.Type().FieldByName(name)
+		if !ok || sf.PkgPath != "" {
+			return false
+		}
+		fv, ok := fieldByIndex(v, sf.Index)
+		if !ok || !m.Matches(fv.Interface()) {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, but reports false instead
+// of panicking when the field is promoted through a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, v.CanInterface()
+}
+
+func (f fieldsMatcher) String() string {
+	names := make([]string, 0, len(f.fields))
+	for name := range f.fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	constraints := make([]string, len(names))
+	for i, name := range names {
+		constraints[i] = name + " " + f.fields[name].String()
+	}
+	return "has fields: " + strings.Join(constraints, ", ")
+}
+
+// capturer is implemented by matchers that store the argument of a call.
+// Matches stashes each argument it accepts, and the Call passed the matcher
+// calls capture once the call actually matches, so that arguments of calls
+// matching other expected calls are not captured.
+type capturer interface {
+	Matcher
+	capture()
+}
+
+type captureMatcher struct {
+	dest    reflect.Value // the pointee of the pointer given to Capture
+	pending reflect.Value
+}
+
+func (m *captureMatcher) Matches(x interface{}) bool {
+	v := reflect.ValueOf(x)
+	if !v.IsValid() {
+		switch m.dest.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+			v = reflect.Zero(m.dest.Type())
+		default:
+			panic(fmt.Sprintf("gomock: Capture cannot store nil into a destination of type %v", m.dest.Type()))
+		}
+	}
+	if !v.Type().AssignableTo(m.dest.Type()) {
+		panic(fmt.Sprintf("gomock: Capture cannot store an argument of type %v into a destination of type %v", v.Type(), m.dest.Type()))
+	}
+	m.pending = v
+	return true
+}
+
+func (m *captureMatcher) capture() {
+	m.dest.Set(m.pending)
+}
+
+func (m *captureMatcher) String() string {
+	return fmt.Sprintf("captures argument of type %v", m.dest.Type())
+}
+
+// A Captor is a Matcher that matches any argument and records the argument
+// of each call matched by the expected call it is passed to, for inspection
+// after the calls are made. It must be passed directly as an argument of the
+// expected call, not within another matcher. It is safe for concurrent use.
+//
+// Example usage:
+//   req := gomock.NewCaptor()
+//   mock.EXPECT().Send(req).Times(2)
+//   // ...
+//   checkRequest(t, req.Value(1).(*Request))
+type Captor struct {
+	mu      sync.Mutex
+	pending interface{}
+	values  []interface{}
+}
+
+// NewCaptor returns a new Captor without any recorded values.
+func NewCaptor() *Captor {
+	return new(Captor)
+}
+
+// Matches implements Matcher. It matches any argument.
+func (c *Captor) Matches(x interface{}) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pending = x
+	return true
+}
+
+func (c *Captor) capture() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values = append(c.values, c.pending)
+}
+
+// String implements Matcher.
+func (c *Captor) String() string {
+	return "captures argument"
+}
+
+// Values returns the captured arguments, in the order the calls were made.
+func (c *Captor) Values() []interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	values := make([]interface{}, len(c.values))
+	copy(values, c.values)
+	return values
+}
+
+// Value returns the argument of the nth call captured, counting from 0. It
+// panics if fewer than n+1 calls were captured.
+func (c *Captor) Value(n int) interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if n < 0 || n >= len(c.values) {
+		panic(fmt.Sprintf("gomock: Captor.Value(%d) called with %d captured values", n, len(c.values)))
+	}
+	return c.values[n]
+}
+
+// Constructors
+
+// All returns a composite Matcher that returns true if and only all of the
+// matchers return true.
+func All(ms ...Matcher) Matcher { return allMatcher{ms} }
+
+// AnyOf returns a composite Matcher that returns true if at least one of the
+// matchers returns true.
+//
+// Example usage:
+//   AnyOf(Eq(1), Eq(2)).Matches(2) // returns true
+//   AnyOf(Eq(1), Eq(2)).Matches(3) // returns false
+func AnyOf(ms ...Matcher) Matcher { return anyOfMatcher{matchers: ms} }
+
+// AnyOfTracked is like AnyOf, but each time it matches a value it also stores
+// into dest the index of the first of the matchers that returned true. Values
+// it does not match leave dest unchanged, since the matcher is also tried
+// against calls that end up matching other expected calls.
+//
+// Example usage:
+//   var i int
+//   AnyOfTracked(&i, Eq(1), Eq(2)).Matches(2) // returns true, sets i to 1
+func AnyOfTracked(dest *int, ms ...Matcher) Matcher { return anyOfMatcher{ms, dest} }
+
+// Any returns a matcher that always matches. It is a GotFormatter, so when
+// another argument of a call fails to match, the argument it matched is shown
+// in the failure message.
+func Any() Matcher { return anyMatcher{} }
+
+// Eq returns a matcher that matches on equality.
+//
+// Example usage:
+//   Eq(5).Matches(5) // returns true
+//   Eq(5).Matches(4) // returns false
+func Eq(x interface{}) Matcher { return eqMatcher{x} }
+
+// Same returns a matcher that matches the very pointer, channel or map x,
+// rather than one that is merely equal to it. A channel matches regardless of
+// its direction, so a bidirectional channel matches the receive-only or
+// send-only channel it was converted to. Same panics if x is not a pointer,
+// channel or map.
+//
+// Example usage:
+//   ch := make(chan int)
+//   Same(ch).Matches((<-chan int)(ch)) // returns true
+//   Same(ch).Matches(make(chan int)) // returns false
+func Same(x interface{}) Matcher {
+	v := reflect.ValueOf(x)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Chan, reflect.Map, reflect.UnsafePointer:
+		return sameMatcher{v}
+	}
+	panic(fmt.Sprintf("gomock: invalid value %v of type %T for Same: it must be a pointer, channel or map", x, x))
+}
+
+// Between returns a matcher that matches a number within the inclusive range
+// from low to high. low, high and the matched value may be of any integer or
+// floating-point types, which are compared by value. Anything else does not
+// match. Between panics if low or high is not a number, or if low is greater
+// than high, so that an invalid range is reported when the expectation is set
+// up.
+//
+// Example usage:
+//   Between(1, 10).Matches(uint8(10)) // returns true
+//   Between(1, 10).Matches(10.5) // returns false
+func Between(low, high interface{}) Matcher {
+	cmp, ok := compareNumbers(reflect.ValueOf(low), reflect.ValueOf(high))
+	if !ok {
+		panic(fmt.Sprintf("gomock: invalid range [%v, %v] for Between: bounds must be numbers", low, high))
+	}
+	if cmp > 0 {
+		panic(fmt.Sprintf("gomock: invalid range [%v, %v] for Between: low is greater than high", low, high))
+	}
+	return betweenMatcher{low, high}
+}
+
+// EqExported returns a matcher that matches a value deeply equal to x, as by
+// Eq, except that the unexported fields of structs, at any depth, are not
+// compared. This ignores internal state such as caches that may differ
+// between otherwise equal values.
+//
+// Example usage:
+//   EqExported(user{Name: "a", cache: 1}).Matches(user{Name: "a", cache: 2}) // returns true
+//   EqExported(user{Name: "a"}).Matches(user{Name: "b"}) // returns false
+func EqExported(x interface{}) Matcher { return eqExportedMatcher{x} }
+
+// SliceEqOption configures how a matcher returned by SliceEq compares slices.
+type SliceEqOption interface {
+	apply(*sliceEqMatcher)
+}
+
+type nilEqualsEmptyOption struct{}
+
+func (nilEqualsEmptyOption) apply(m *sliceEqMatcher) {
+	m.nilEqualsEmpty = true
+}
+
+// NilEqualsEmpty returns a SliceEqOption that makes a nil slice equal to an
+// empty one, which SliceEq otherwise tells apart like Eq does.
+func NilEqualsEmpty() SliceEqOption { return nilEqualsEmptyOption{} }
+
+// SliceEq returns a matcher that matches a slice of the same type as expected
+// with equal elements in the same order. It agrees with Eq, but compares slices
+// of booleans, numbers and strings element by element, which is much faster
+// than Eq for large slices. Elements of other types are compared with
+// reflect.DeepEqual. SliceEq panics if expected is not a slice.
+//
+// Example usage:
+//   SliceEq([]int{1, 2}).Matches([]int{1, 2}) // returns true
+//   SliceEq([]int{}).Matches([]int(nil)) // returns false
+//   SliceEq([]int{}, NilEqualsEmpty()).Matches([]int(nil)) // returns true
+func SliceEq(expected interface{}, opts ...SliceEqOption) Matcher {
+	if reflect.ValueOf(expected).Kind() != reflect.Slice {
+		panic(fmt.Sprintf("gomock: SliceEq of %T, which is not a slice", expected))
+	}
+	m := sliceEqMatcher{expected: expected}
+	for _, opt := range opts {
+		opt.apply(&m)
+	}
+	return m
+}
+
+// GreaterThan returns a matcher that matches a number greater than x. x and
+// the matched value may be of any integer or floating-point types, which are
+// compared by value. Anything else does not match. GreaterThan panics if x is
+// not a number, so that the mistake is reported when the expectation is set
+// up; the same goes for LessThan, GreaterThanOrEqual and LessThanOrEqual.
+//
+// Example usage:
+//   GreaterThan(int32(5)).Matches(6) // returns true
+//   GreaterThan(5).Matches(5.0) // returns false
+func GreaterThan(x interface{}) Matcher {
+	return newCompareMatcher("GreaterThan", x, "greater than", func(c int) bool { return c > 0 })
+}
+
+// LessThan returns a matcher that matches a number less than x, compared
+// like by GreaterThan.
+//
+// Example usage:
+//   LessThan(uint8(5)).Matches(-1) // returns true
+//   LessThan(5).Matches(5) // returns false
+func LessThan(x interface{}) Matcher {
+	return newCompareMatcher("LessThan", x, "less than", func(c int) bool { return c < 0 })
+}
+
+// GreaterThanOrEqual returns a matcher that matches a number greater than or
+// equal to x, compared like by GreaterThan.
+//
+// Example usage:
+//   GreaterThanOrEqual(5).Matches(uint(5)) // returns true
+//   GreaterThanOrEqual(5).Matches(4.9) // returns false
+func GreaterThanOrEqual(x interface{}) Matcher {
+	return newCompareMatcher("GreaterThanOrEqual", x, "greater than or equal to", func(c int) bool { return c >= 0 })
+}
+
+// LessThanOrEqual returns a matcher that matches a number less than or equal
+// to x, compared like by GreaterThan.
+//
+// Example usage:
+//   LessThanOrEqual(5).Matches(int64(5)) // returns true
+//   LessThanOrEqual(5).Matches(5.1) // returns false
+func LessThanOrEqual(x interface{}) Matcher {
+	return newCompareMatcher("LessThanOrEqual", x, "less than or equal to", func(c int) bool { return c <= 0 })
+}
+
+func newCompareMatcher(constructor string, bound interface{}, name string, ok func(c int) bool) Matcher {
+	v := reflect.ValueOf(bound)
+	if _, isNumber := compareNumbers(v, v); !isNumber {
+		panic(fmt.Sprintf("gomock: invalid bound %v for %s: it must be a number", bound, constructor))
+	}
+	return compareMatcher{bound, name, ok}
+}
+
+// EqFold returns a matcher that matches a string, or a fmt.Stringer whose
+// String method returns a string, equal to expected under Unicode case
+// folding. Any other value does not match.
+//
+// Example usage:
+//   EqFold("Content-Type").Matches("content-type") // returns true
+//   EqFold("Content-Type").Matches("Content-Length") // returns false
+func EqFold(expected string) Matcher { return eqFoldMatcher{expected} }
+
+// ContextHasValue returns a matcher that matches a context.Context whose
+// value for key is not nil and equal to expected, or matched by expected if it
+// is a Matcher. Anything that is not a context does not match.
+//
+// Example usage:
+//   ctx := context.WithValue(context.Background(), userKey, "alice")
+//   ContextHasValue(userKey, "alice").Matches(ctx) // returns true
+//   ContextHasValue(userKey, "bob").Matches(ctx) // returns false
+func ContextHasValue(key, expected interface{}) Matcher {
+	m, ok := expected.(Matcher)
+	if !ok {
+		m = Eq(expected)
+	}
+	return contextValueMatcher{key, m}
+}
+
+// FieldsMatcher returns a matcher that matches a struct, or a non-nil pointer
+// to a struct, each of whose fields named in fields has a value matched by the
+// field's matcher. Other fields are ignored, so a field that should only be
+// compared loosely can be left out, or matched with Any. Nothing matches if one
+// of the named fields is missing or unexported.
+//
+// Example usage:
+//   type user struct {
+//     Name      string
+//     CreatedAt time.Time
+//   }
+//   m := FieldsMatcher(map[string]Matcher{"Name": Eq("x"), "CreatedAt": Any()})
+//   m.Matches(user{Name: "x", CreatedAt: time.Now()}) // returns true
+//   m.Matches(user{Name: "y"}) // returns false
+func FieldsMatcher(fields map[string]Matcher) Matcher {
+	copied := make(map[string]Matcher, len(fields))
+	for name, m := range fields {
+		copied[name] = m
+	}
+	return fieldsMatcher{copied}
+}
+
+// FieldByTag returns a matcher that matches a struct, or a non-nil pointer to
+// a struct, whose first exported field with a tagKey tag equal to tagValue has
+// a value matching m. Options after a comma in the tag, as in
+// `json:"name,omitempty"`, are ignored. Nothing matches if there is no such
+// field.
+//
+// Example usage:
+//   type user struct {
+//     Name string `json:"name,omitempty"`
+//   }
+//   FieldByTag("json", "name", Eq("Fido")).Matches(user{Name: "Fido"}) // returns true
+//   FieldByTag("json", "id", Eq("Fido")).Matches(user{Name: "Fido"}) // returns false
+func FieldByTag(tagKey, tagValue string, m Matcher) Matcher {
+	return fieldByTagMatcher{tagKey, tagValue, m}
+}
+
+// EqField returns a matcher that matches a value equal to the field named
+// fieldName of expectedStruct, which is a struct or a non-nil pointer to one.
+// It is handy when the expected value is part of a fixture. EqField panics if
+// expectedStruct has no such exported field.
+//
+// Example usage:
+//   fixture := struct{ ID int }{ID: 42}
+//   EqField(fixture, "ID").Matches(42) // returns true
+//   EqField(&fixture, "ID").Matches(43) // returns false
+func EqField(expectedStruct interface{}, fieldName string) Matcher {
+	v := reflect.ValueOf(expectedStruct)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("gomock: invalid value %v of type %T for EqField: it must be a struct or a non-nil pointer to one",
+			expectedStruct, expectedStruct))
+	}
+	sf, ok := v.Type().FieldByName(fieldName)
+	if !ok || sf.PkgPath != "" {
+		panic(fmt.Sprintf("gomock: invalid field name %q for EqField: %v has no exported field of that name",
+			fieldName, v.Type()))
+	}
+	fv, ok := fieldByIndex(v, sf.Index)
+	if !ok {
+		panic(fmt.Sprintf("gomock: invalid field name %q for EqField: it is promoted through a nil embedded pointer of %v",
+			fieldName, v.Type()))
+	}
+	return eqFieldMatcher{eqMatcher{fv.Interface()}, fieldName, v.Type()}
+}
+
+// BigEq returns a matcher that matches a value of the same type as expected
+// that expected.Cmp reports to be equal to it, so that, for example, a
+// *big.Int matches regardless of how it was computed. expected must be a
+// pointer with a method Cmp(y T) int, where T is the type of expected, such as
+// *big.Int, *big.Rat or *big.Float; otherwise BigEq panics. Values of other
+// types do not match.
+//
+// Example usage:
+//   BigEq(big.NewInt(100)).Matches(new(big.Int).Exp(big.NewInt(10), big.NewInt(2), nil)) // returns true
+//   BigEq(big.NewInt(100)).Matches(big.NewRat(100, 1)) // returns false
+func BigEq(expected interface{}) Matcher {
+	v := reflect.ValueOf(expected)
+	if v.Kind() == reflect.Ptr {
+		if cmp := v.MethodByName("Cmp"); cmp.IsValid() {
+			t := cmp.Type()
+			if t.NumIn() == 1 && t.In(0) == v.Type() && !t.IsVariadic() && t.NumOut() == 1 && t.Out(0).Kind() == reflect.Int {
+				return bigEqMatcher{v, cmp}
+			}
+		}
+	}
+	panic(fmt.Sprintf("gomock: invalid value %v of type %T for BigEq: it must be a pointer with a method Cmp(%T) int",
+		expected, expected, expected))
+}
+
+// ApproxEq returns a matcher that matches a float64 or float32 within
+// tolerance of value, that is, with math.Abs(x-value) <= tolerance. float32
+// values are converted to float64 before they are compared. NaN never
+// matches, and an infinite value only matches the same infinity. ApproxEq
+// panics if tolerance is negative or NaN.
+//
+// Example usage:
+//   ApproxEq(3.14, 0.01).Matches(3.1415) // returns true
+//   ApproxEq(3.14, 0.01).Matches(float32(3.2)) // returns false
+func ApproxEq(value, tolerance float64) Matcher {
+	if !(tolerance >= 0) {
+		panic(fmt.Sprintf("gomock: invalid tolerance %v for ApproxEq: it must not be negative", tolerance))
+	}
+	return approxEqMatcher{value, tolerance}
+}
+
+// SliceInDelta returns a matcher that matches a slice of float64 or float32
+// with the same length as expected, each of whose elements is within delta of
+// the corresponding element of expected. Elements of float32 slices are
+// converted to float64 before they are compared. SliceInDelta panics if delta
+// is negative or NaN.
+//
+// Example usage:
+//   SliceInDelta([]float64{1, 2}, 0.01).Matches([]float64{1.001, 1.999}) // returns true
+//   SliceInDelta([]float64{1, 2}, 0.01).Matches([]float32{1, 2.1}) // returns false
+func SliceInDelta(expected []float64, delta float64) Matcher {
+	if !(delta >= 0) {
+		panic(fmt.Sprintf("gomock: invalid delta %v for SliceInDelta: it must not be negative", delta))
+	}
+	return sliceInDeltaMatcher{expected, delta}
+}
+
+// GobRoundTrips returns a matcher that matches a value that, once encoded
+// with encoding/gob and decoded into a new value of the same type, is deeply
+// equal to the decoded value. Values that fail to encode or decode do not
+// match, nor do values referring back to themselves, which gob cannot encode.
+// Note that gob decodes empty slices and maps as nil, and does not transmit
+// unexported fields, so values holding them do not round-trip.
+//
+// Example usage:
+//   GobRoundTrips().Matches(Dog{Breed: "pug"}) // returns true
+//   GobRoundTrips().Matches(func() {}) // returns false
+func GobRoundTrips() Matcher { return gobRoundTripsMatcher{} }
+
+// InAnyOrder returns a matcher that matches an array or slice holding the same
+// elements as the array or slice x, in any order. Each element of x is a
+// Matcher, or else is matched by Eq, and must match a different element of
+// the value, so repeated elements are matched as many times as they occur.
+// InAnyOrder panics if x is not an array or slice.
+//
+// Example usage:
+//   InAnyOrder([]int{1, 2, 1}).Matches([]int{2, 1, 1}) // returns true
+//   InAnyOrder([]int{1, 2, 1}).Matches([]int{1, 2, 2}) // returns false
+//   InAnyOrder([]interface{}{Eq(1), Any()}).Matches([]int{5, 1}) // returns true
+func InAnyOrder(x interface{}) Matcher {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Array && v.Kind() != reflect.Slice {
+		panic(fmt.Sprintf("gomock: InAnyOrder of %T, which is not an array or slice", x))
+	}
+	matchers := make([]Matcher, v.Len())
+	for i := range matchers {
+		e := v.Index(i).Interface()
+		if m, ok := e.(Matcher); ok {
+			matchers[i] = m
+		} else {
+			matchers[i] = Eq(e)
+		}
+	}
+	return inAnyOrderMatcher{x, matchers}
+}
+
+// Len returns a matcher that matches on length. This matcher returns false if
+// is compared to a type that is not an array, chan, map, slice, or string.
+func Len(i int) Matcher {
+	return lenMatcher{i}
+}
+
+// LenMatches returns a matcher that matches an array, chan, map, slice, or
+// string whose length, as an int, is matched by m. This matcher returns false
+// if is compared to any other type.
+//
+// Example usage:
+//   LenMatches(Not(Eq(0))).Matches([]int{1}) // returns true
+//   LenMatches(Between(1, 3)).Matches("abcd") // returns false
+func LenMatches(m Matcher) Matcher { return lenMatchesMatcher{m} }
+
+// Empty returns a matcher that matches an array, chan, map, slice, or string
+// of length zero. A nil value is considered empty. This matcher returns false
+// if is compared to any other type.
+//
+// Example usage:
+//   var s []int
+//   Empty().Matches(s) // returns true
+//   Empty().Matches("abc") // returns false
+func Empty() Matcher { return emptyMatcher{true} }
+
+// NotEmpty returns a matcher that matches an array, chan, map, slice, or
+// string with at least one element. This matcher returns false if is compared
+// to nil or to any other type.
+//
+// Example usage:
+//   NotEmpty().Matches(map[string]int{"a": 1}) // returns true
+//   NotEmpty().Matches([]int{}) // returns false
+func NotEmpty() Matcher { return emptyMatcher{false} }
+
+// MapKeys returns a matcher that matches a map whose set of keys is exactly
+// the given keys, in any order. Duplicate keys are ignored. This matcher
+// returns false if is compared to a type that is not a map. MapKeys panics if
+// a key is of a type that cannot be a map key, such as a slice.
+//
+// Example usage:
+//   MapKeys("a", "b").Matches(map[string]int{"b": 2, "a": 1}) // returns true
+//   MapKeys("a").Matches(map[string]int{"a": 1, "b": 2}) // returns false
+func MapKeys(keys ...interface{}) Matcher {
+	seen := make(map[interface{}]bool, len(keys))
+	distinct := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		if t := reflect.TypeOf(key); t != nil && !t.Comparable() {
+			panic(fmt.Sprintf("gomock: invalid key %v of type %T for MapKeys: it is not comparable", key, key))
+		}
+		if !seen[key] {
+			seen[key] = true
+			distinct = append(distinct, key)
+		}
+	}
+	return mapKeysMatcher{distinct}
+}
+
+// Contains returns a matcher that matches a string containing the substring
+// x, an array or slice with an element matching x, or a map with a key matching
+// x. Elements and keys are matched by x itself if it is a Matcher, or else by
+// Eq(x). Anything else, including nil, does not match.
+//
+// Example usage:
+//   Contains("token").Matches("got token 42") // returns true
+//   Contains(2).Matches([]int{1, 2, 3}) // returns true
+//   Contains(Len(1)).Matches(map[string]int{"a": 1}) // returns true
+//   Contains("a").Matches(nil) // returns false
+func Contains(x interface{}) Matcher { return containsMatcher{x} }
+
+// ContainsSubsequence returns a matcher that matches an array or slice with
+// elements matching elems in the same order, though not necessarily next to
+// one another. Each of elems matches elements by itself if it is a Matcher, or
+// else by Eq. Anything else, including nil, does not match.
+//
+// Example usage:
+//   ContainsSubsequence(1, 3).Matches([]int{1, 2, 3}) // returns true
+//   ContainsSubsequence(3, 1).Matches([]int{1, 2, 3}) // returns false
+//   ContainsSubsequence("a", Any()).Matches([]string{"a"}) // returns false
+func ContainsSubsequence(elems ...interface{}) Matcher {
+	matchers := make([]Matcher, len(elems))
+	for i, e := range elems {
+		if m, ok := e.(Matcher); ok {
+			matchers[i] = m
+		} else {
+			matchers[i] = Eq(e)
+		}
+	}
+	return containsSubsequenceMatcher{matchers}
+}
+
+// Capture returns a matcher that matches any argument assignable to the type
+// pointed to by dest, and stores it in *dest when a call is matched by the
+// expected call it is passed to. Like a Captor, it must be passed directly as
+// an argument of the expected call; use a Captor to record the arguments of
+// all calls rather than the last one. Capture panics if dest is not a non-nil
+// pointer, and the matcher panics, naming both types, when given an argument
+// that cannot be stored in *dest, rather than reporting an unexpected call.
+//
+// Example usage:
+//   var req *Request
+//   mock.EXPECT().Send(gomock.Capture(&req))
+func Capture(dest interface{}) Matcher {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		panic(fmt.Sprintf("gomock: invalid destination %v of type %T for Capture: it must be a non-nil pointer", dest, dest))
+	}
+	return &captureMatcher{dest: v.Elem()}
+}
+
+// JSONEq returns a matcher that matches a string or []byte holding JSON that
+// is semantically equal to expected, that is, regardless of whitespace and
+// the order of object keys. Values that are not valid JSON do not match.
+// JSONEq panics if expected is not valid JSON.
+//
+// Example usage:
+//   JSONEq(`{"a": 1, "b": [true]}`).Matches([]byte(`{"b":[true],"a":1}`)) // returns true
+//   JSONEq(`{"a": 1}`).Matches(`{"a": "1"}`) // returns false
+func JSONEq(expected string) Matcher {
+	var want interface{}
+	if err := json.Unmarshal([]byte(expected), &want); err != nil {
+		panic(fmt.Sprintf("gomock: invalid JSON %q for JSONEq: %v", expected, err))
+	}
+	// Values unmarshaled into an interface{} always marshal.
+	canonical, _ := json.Marshal(want)
+	return jsonEqMatcher{want, string(canonical)}
+}
+
+// MarshalsTo returns a matcher that matches a value whose encoding by
+// json.Marshal is semantically equal to expectedJSON, that is, regardless of
+// whitespace and the order of object keys. It does not match values that fail
+// to marshal. MarshalsTo panics if expectedJSON is not valid JSON.
+//
+// Example usage:
+//   MarshalsTo(`{"a": 1}`).Matches(map[string]int{"a": 1}) // returns true
+//   MarshalsTo(`{"a": 1}`).Matches(map[string]int{"a": 2}) // returns false
+func MarshalsTo(expectedJSON string) Matcher {
+	var want interface{}
+	if err := json.Unmarshal([]byte(expectedJSON), &want); err != nil {
+		panic(fmt.Sprintf("gomock: invalid JSON %q for MarshalsTo: %v", expectedJSON, err))
+	}
+	return marshalsToMatcher{expectedJSON, want}
+}
+
+// Regexp returns a matcher that matches a string, a []byte, or a fmt.Stringer
+// whose String method returns a string, in which the regular expression
+// pattern finds a match. Regexp panics if pattern does not compile, so that
+// the mistake is reported when the expectation is set up.
+//
+// Example usage:
+//   Regexp(`^/users/\d+$`).Matches("/users/42") // returns true
+//   Regexp(`^/users/\d+$`).Matches("/users/me") // returns false
+func Regexp(pattern string) Matcher {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		panic(fmt.Sprintf("gomock: invalid pattern %q for Regexp: %v", pattern, err))
+	}
+	return regexpMatcher{re}
+}
+
+// RegexpMatch is like Regexp, but takes an already compiled regular
+// expression.
+func RegexpMatch(re *regexp.Regexp) Matcher { return regexpMatcher{re} }
+
+// RegexCapture returns a matcher that matches a string, or a fmt.Stringer
+// whose String method returns a string, in which the regular expression
+// pattern finds a match whose capturing group numbered group matches m. Group
+// 0 is the whole match; a group that takes no part in the match is the empty
+// string. RegexCapture panics if pattern does not compile or has no such
+// group, so that the mistake is reported when the expectation is set up.
+//
+// Example usage:
+//   RegexCapture(`user/(\d+)`, 1, Eq("42")).Matches("/api/user/42") // returns true
+//   RegexCapture(`user/(\d+)`, 1, Eq("42")).Matches("/api/user/7") // returns false
+func RegexCapture(pattern string, group int, m Matcher) Matcher {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		panic(fmt.Sprintf("gomock: invalid pattern %q for RegexCapture: %v", pattern, err))
+	}
+	if group < 0 || group > re.NumSubexp() {
+		panic(fmt.Sprintf("gomock: pattern %q for RegexCapture has no group %d", pattern, group))
+	}
+	return regexCaptureMatcher{re, group, m}
+}
+
+// Nil returns a matcher that matches if the received value is nil.
+//
+// Example usage:
+//   var x *bytes.Buffer
+//   Nil().Matches(x) // returns true
+//   x = &bytes.Buffer{}
+//   Nil().Matches(x) // returns false
+func Nil() Matcher { return nilMatcher{} }
+
+// NonNilPtr returns a matcher that matches a non-nil pointer whose pointee
+// matches inner.
+//
+// Example usage:
+//   x := 5
+//   NonNilPtr(Eq(5)).Matches(&x) // returns true
+//   NonNilPtr(Eq(5)).Matches((*int)(nil)) // returns false
+func NonNilPtr(inner Matcher) Matcher { return nonNilPtrMatcher{inner} }
+
+// PtrEqWith returns a matcher that matches a non-nil pointer whose pointee is
+// equal to expected according to eq, which is called with expected and the
+// pointee, in that order. Nil pointers and non-pointers do not match.
+// PtrEqWith panics if eq is nil.
+//
+// Example usage:
+//   sameName := func(a, b interface{}) bool { return a.(Dog).Name == b.(Dog).Name }
+//   PtrEqWith(Dog{Name: "Fido"}, sameName).Matches(&Dog{Name: "Fido", Breed: "pug"}) // returns true
+//   PtrEqWith(Dog{Name: "Fido"}, sameName).Matches((*Dog)(nil)) // returns false
+func PtrEqWith(expected interface{}, eq func(a, b interface{}) bool) Matcher {
+	if eq == nil {
+		panic("gomock: nil comparison function for PtrEqWith")
+	}
+	return nonNilPtrMatcher{eqWithMatcher{expected, eq}}
+}
+
+// Not reverses the results of its given child matcher.
+//
+// Example usage:
+//   Not(Eq(5)).Matches(4) // returns true
+//   Not(Eq(5)).Matches(5) // returns false
+func Not(x interface{}) Matcher {
+	if m, ok := x.(Matcher); ok {
+		return notMatcher{m}
+	}
+	return notMatcher{Eq(x)}
+}
+
+// Ne returns a matcher that matches any value not equal to x. It is shorthand
+// for Not(x), so x may itself be a Matcher.
+//
+// Example usage:
+//   Ne(5).Matches(4) // returns true
+//   Ne(5).Matches(5) // returns false
+func Ne(x interface{}) Matcher { return Not(x) }
+
+// OneOf returns a matcher that matches a value equal to at least one of vals.
+// Values that are Matchers are used as they are, and other values are compared
+// with Eq. With no vals, the matcher never matches.
+//
+// Example usage:
+//   OneOf(1, 2).Matches(2) // returns true
+//   OneOf(1, 2).Matches(3) // returns false
+//   OneOf("a", Len(2)).Matches("bc") // returns true
+func OneOf(vals ...interface{}) Matcher {
+	matchers := make([]Matcher, len(vals))
+	for i, v := range vals {
+		if m, ok := v.(Matcher); ok {
+			matchers[i] = m
+		} else {
+			matchers[i] = Eq(v)
+		}
+	}
+	return oneOfMatcher{anyOfMatcher{matchers: matchers}}
+}
+
+// Implements returns a matcher that matches a non-nil value whose dynamic type
+// implements the interface pointed to by ifacePtr, which is given as a nil
+// pointer to the interface type. Implements panics if ifacePtr is not a
+// pointer to an interface type.
+//
+// Example usage:
+//   Implements((*io.Reader)(nil)).Matches(strings.NewReader("x")) // returns true
+//   Implements((*io.Reader)(nil)).Matches("x") // returns false
+//   Implements((*io.Reader)(nil)).Matches(nil) // returns false
+func Implements(ifacePtr interface{}) Matcher {
+	t := reflect.TypeOf(ifacePtr)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Interface {
+		panic(fmt.Sprintf("gomock: invalid value %v of type %T for Implements: it must be a pointer to an interface, such as (*io.Reader)(nil)",
+			ifacePtr, ifacePtr))
+	}
+	return implementsMatcher{t.Elem()}
 }
 
 // AssignableToTypeOf is a Matcher that matches if the parameter to the mock
@@ -0,0 +1,100 @@
+// Copyright 2010 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.13
+// +build go1.13
+
+package gomock
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+type errorIsMatcher struct {
+	target error
+}
+
+func (m errorIsMatcher) Matches(x interface{}) bool {
+	err, ok := x.(error)
+	return ok && err != nil && errors.Is(err, m.target)
+}
+
+func (m errorIsMatcher) String() string {
+	if m.target == nil {
+		return "is an error wrapping <nil>"
+	}
+	return fmt.Sprintf("is an error wrapping %q", m.target.Error())
+}
+
+type errorAsMatcher struct {
+	target interface{}
+}
+
+func (m errorAsMatcher) Matches(x interface{}) bool {
+	err, ok := x.(error)
+	if !ok || err == nil {
+		return false
+	}
+	// errors.As panics on targets other than non-nil pointers to interfaces
+	// or to types implementing error.
+	v := reflect.ValueOf(m.target)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return false
+	}
+	if t := v.Type().Elem(); t.Kind() != reflect.Interface && !t.Implements(errorType) {
+		return false
+	}
+	return errors.As(err, m.target)
+}
+
+func (m errorAsMatcher) String() string {
+	t := reflect.TypeOf(m.target)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return fmt.Sprintf("is an error assignable to %T", m.target)
+	}
+	return fmt.Sprintf("is an error assignable to %v", t.Elem())
+}
+
+// Constructors
+
+// ErrorIs returns a matcher that matches an error for which
+// errors.Is(err, target) returns true, i.e. an error whose chain contains
+// target. Nil and values that are not errors do not match.
+//
+// ErrorIs requires Go 1.13 or later.
+//
+// Example usage:
+//   ErrorIs(io.EOF).Matches(fmt.Errorf("read: %w", io.EOF)) // returns true
+//   ErrorIs(io.EOF).Matches(errors.New("EOF")) // returns false
+func ErrorIs(target error) Matcher { return errorIsMatcher{target} }
+
+// ErrorAs returns a matcher that matches an error for which
+// errors.As(err, targetPtr) returns true, i.e. an error whose chain contains
+// an error assignable to the type targetPtr points to. targetPtr must be a
+// non-nil pointer to an interface type or to a type implementing error;
+// otherwise nothing matches.
+//
+// As a side effect, each successful match sets *targetPtr to the error found,
+// so the matched error can be inspected after the mock was called. Since
+// the target is shared, it holds the error of the last match.
+//
+// ErrorAs requires Go 1.13 or later.
+//
+// Example usage:
+//   var pathErr *os.PathError
+//   ErrorAs(&pathErr).Matches(fmt.Errorf("open: %w", &os.PathError{})) // returns true, sets pathErr
+//   ErrorAs(&pathErr).Matches(errors.New("open")) // returns false
+func ErrorAs(targetPtr interface{}) Matcher { return errorAsMatcher{targetPtr} }
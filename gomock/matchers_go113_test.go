@@ -0,0 +1,77 @@
+// Copyright 2010 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.13
+// +build go1.13
+
+package gomock_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestErrorIs(t *testing.T) {
+	m := gomock.ErrorIs(io.EOF)
+	for _, x := range []interface{}{io.EOF, fmt.Errorf("read: %w", io.EOF), fmt.Errorf("twice: %w", fmt.Errorf("read: %w", io.EOF))} {
+		if !m.Matches(x) {
+			t.Errorf("%v did not match %v", m, x)
+		}
+	}
+	for _, x := range []interface{}{errors.New("EOF"), fmt.Errorf("read: %v", io.EOF), io.ErrUnexpectedEOF, nil, (error)(nil), "EOF"} {
+		if m.Matches(x) {
+			t.Errorf("%v matched %v", m, x)
+		}
+	}
+	if gomock.ErrorIs(nil).Matches(nil) {
+		t.Error("ErrorIs(nil) matched nil")
+	}
+
+	if got, want := m.String(), `is an error wrapping "EOF"`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestErrorAs(t *testing.T) {
+	var target *codeError
+	m := gomock.ErrorAs(&target)
+
+	if !m.Matches(fmt.Errorf("request failed: %w", &codeError{code: 404})) {
+		t.Fatal("wrapped *codeError did not match")
+	}
+	if target == nil || target.code != 404 {
+		t.Errorf("target = %v, want the wrapped *codeError with code 404", target)
+	}
+
+	for _, x := range []interface{}{errors.New("code 404"), nil, (error)(nil), "code 404", codeError{code: 404}} {
+		if m.Matches(x) {
+			t.Errorf("%v matched %v", m, x)
+		}
+	}
+
+	// Invalid targets match nothing rather than panic.
+	for _, invalid := range []interface{}{nil, target, (*error)(nil), new(int)} {
+		if gomock.ErrorAs(invalid).Matches(&codeError{}) {
+			t.Errorf("ErrorAs(%T) matched", invalid)
+		}
+	}
+
+	if got, want := m.String(), "is an error assignable to *gomock_test.codeError"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,31 @@
+package gomock
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHasCycle_SharedValues(t *testing.T) {
+	type node struct {
+		left, right *node
+		items       []*node
+	}
+	// Each node refers to the next one three times, so there are 3^100 paths
+	// to the last node, which must not all be walked.
+	var n *node
+	for i := 0; i < 100; i++ {
+		n = &node{left: n, right: n, items: []*node{n}}
+	}
+	if hasCycle(reflect.ValueOf(n), make(map[visit]bool), make(map[visit]bool)) {
+		t.Error("hasCycle() = true for shared values without a cycle")
+	}
+
+	last := n
+	for last.left != nil {
+		last = last.left
+	}
+	last.right = n
+	if !hasCycle(reflect.ValueOf(n), make(map[visit]bool), make(map[visit]bool)) {
+		t.Error("hasCycle() = false for a cycle through shared values")
+	}
+}
@@ -17,10 +17,19 @@
 package gomock_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
 	"reflect"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/golang/mock/gomock/internal/mock_gomock"
@@ -40,10 +49,165 @@ func TestMatchers(t *testing.T) {
 			[]e{"", 0, make(chan bool), errors.New("err"), new(int)}},
 		{"test Not", gomock.Not(gomock.Eq(4)), []e{3, "blah", nil, int64(4)}, []e{4}},
 		{"test All", gomock.All(gomock.Any(), gomock.Eq(4)), []e{4}, []e{3, "blah", nil, int64(4)}},
+		{"test AnyOf", gomock.AnyOf(gomock.Eq(4), gomock.Nil()), []e{4, nil}, []e{3, "blah", int64(4)}},
+		{"test Between", gomock.Between(-2, 10.5),
+			[]e{0, -2, 10.5, int8(-1), uint64(10), float32(3.25), uintptr(1)},
+			[]e{-3, 11, 10.51, uint64(math.MaxUint64), math.NaN(), "5", nil},
+		},
+		{"test Between unsigned", gomock.Between(uint(3), uint64(math.MaxUint64)),
+			[]e{3, uint64(math.MaxUint64), int64(math.MaxInt64)},
+			[]e{-1, 2, int64(math.MinInt64), 2.5},
+		},
+		{"test EqFold", gomock.EqFold("Content-Type"),
+			[]e{"Content-Type", "content-type", "CONTENT-TYPE", gomock.StringerFunc(func() string { return "content-TYPE" })},
+			[]e{"Content-Length", "ContentType", "", nil, 42, []byte("content-type")},
+		},
+		{"test Regexp", gomock.Regexp(`^/users/\d+$`),
+			[]e{"/users/42", []byte("/users/7"), gomock.StringerFunc(func() string { return "/users/1" })},
+			[]e{"/users/me", "/api/users/42", []byte("/users/"), 42, nil},
+		},
+		{"test RegexpMatch", gomock.RegexpMatch(regexp.MustCompile(`(?i)^foo`)),
+			[]e{"foo", "FOObar"},
+			[]e{"barfoo", ""},
+		},
+		{"test RegexCapture", gomock.RegexCapture(`user/(\d+)(/\w+)?`, 1, gomock.Eq("42")),
+			[]e{"/api/user/42", "user/42/posts", gomock.StringerFunc(func() string { return "user/42" })},
+			[]e{"/api/user/7", "/api/group/42", "", 42, nil},
+		},
+		{"test RegexCapture unmatched group", gomock.RegexCapture(`user/(\d+)(/\w+)?`, 2, gomock.Eq("")),
+			[]e{"user/42"},
+			[]e{"user/42/posts", "group/42"},
+		},
 		{"test Len", gomock.Len(2),
 			[]e{[]int{1, 2}, "ab", map[string]int{"a": 0, "b": 1}, [2]string{"a", "b"}},
 			[]e{[]int{1}, "a", 42, 42.0, false, [1]string{"a"}},
 		},
+		{"test LenMatches", gomock.LenMatches(gomock.Not(gomock.Eq(0))),
+			[]e{[]int{1}, "a", map[string]int{"a": 1}, [1]string{"a"}},
+			[]e{[]int{}, []int(nil), "", map[string]int{}, [0]int{}, make(chan int), 42, struct{}{}, nil},
+		},
+		{"test Empty", gomock.Empty(),
+			[]e{nil, []int(nil), []int{}, "", map[string]int{}, make(chan int)},
+			[]e{[]int{1}, "a", map[string]int{"a": 1}, 0, struct{}{}},
+		},
+		{"test MapKeys", gomock.MapKeys("a", "b", "a"),
+			[]e{map[string]int{"a": 1, "b": 2}, map[string]bool{"b": true, "a": false}},
+			[]e{
+				map[string]int{"a": 1, "b": 2, "c": 3}, // extra key
+				map[string]int{"a": 1},                 // missing key
+				map[int]int{1: 1, 2: 2},
+				[]string{"a", "b"},
+				nil,
+			},
+		},
+		{"test Contains substring", gomock.Contains("token"),
+			[]e{"got token 42", "token", []string{"token"}, map[string]int{"token": 1}},
+			[]e{"tok", "", []string{"got token 42"}, map[int]string{1: "token"}, []byte("token"), nil},
+		},
+		{"test Contains element", gomock.Contains(2),
+			[]e{[]int{1, 2, 3}, [2]int{2, 4}, map[int]string{2: "b"}},
+			[]e{[]int{1, 3}, []int(nil), map[string]int{"a": 2}, []int64{2}, "2", 2, nil},
+		},
+		{"test Contains nested matcher", gomock.Contains(gomock.Len(2)),
+			[]e{[][]int{{1}, {1, 2}}, map[string]bool{"ab": true}},
+			[]e{[][]int{{1}, {1, 2, 3}}, map[string]bool{"a": true}, "ab", nil},
+		},
+		{"test ContainsSubsequence", gomock.ContainsSubsequence(1, 3, 5),
+			[]e{[]int{1, 3, 5}, []int{0, 1, 2, 3, 4, 5, 6}, [4]int{1, 3, 3, 5}, []int{3, 1, 3, 5}},
+			[]e{[]int{5, 3, 1}, []int{1, 5, 3}, []int{1, 3}, []int64{1, 3, 5}, []int{}, "135", nil},
+		},
+		{"test ContainsSubsequence contiguous", gomock.ContainsSubsequence("b", "c"),
+			[]e{[]string{"a", "b", "c", "d"}, []string{"b", "c"}},
+			[]e{[]string{"c", "b"}, []string{"b"}, []string{"a", "c"}},
+		},
+		{"test ContainsSubsequence matchers", gomock.ContainsSubsequence(gomock.Len(1), gomock.Len(1)),
+			[]e{[]string{"a", "bc", "d"}, []interface{}{"a", []int{1}}},
+			[]e{[]string{"a", "bc"}, []string{"ab", "c"}},
+		},
+		{"test ContainsSubsequence empty", gomock.ContainsSubsequence(),
+			[]e{[]int{}, []string(nil), [0]int{}},
+			[]e{map[int]int{}, "", nil},
+		},
+		{"test Ne", gomock.Ne(5),
+			[]e{4, int64(5), "5", nil},
+			[]e{5},
+		},
+		{"test Ne matcher", gomock.Ne(gomock.Nil()),
+			[]e{0, ""},
+			[]e{nil, (*int)(nil)},
+		},
+		{"test OneOf", gomock.OneOf(1, "a", nil),
+			[]e{1, "a", nil},
+			[]e{2, "b", int64(1), (*int)(nil)},
+		},
+		{"test OneOf matchers", gomock.OneOf("a", gomock.Len(2)),
+			[]e{"a", "bc", []int{1, 2}},
+			[]e{"b", "abc", 2},
+		},
+		{"test OneOf empty", gomock.OneOf(),
+			nil,
+			[]e{nil, 0, "", []int{}},
+		},
+		{"test SliceEq", gomock.SliceEq([]int{1, 2, 3}),
+			[]e{[]int{1, 2, 3}},
+			[]e{[]int{1, 2}, []int{1, 2, 4}, []int64{1, 2, 3}, [3]int{1, 2, 3}, nil},
+		},
+		{"test SliceEq empty", gomock.SliceEq([]string{}),
+			[]e{[]string{}},
+			[]e{[]string(nil), []string{""}},
+		},
+		{"test SliceEq NilEqualsEmpty", gomock.SliceEq([]string{}, gomock.NilEqualsEmpty()),
+			[]e{[]string{}, []string(nil)},
+			[]e{[]string{""}, nil},
+		},
+		{"test InAnyOrder", gomock.InAnyOrder([]string{"a", "b", "a"}),
+			[]e{[]string{"a", "b", "a"}, []string{"b", "a", "a"}, [3]string{"a", "a", "b"}},
+			[]e{[]string{"a", "b", "b"}, []string{"a", "b"}, []string{"a", "b", "a", "c"}, []int{1, 2, 1}, "aba", nil},
+		},
+		{"test InAnyOrder matchers", gomock.InAnyOrder([]interface{}{gomock.Any(), gomock.Eq(1)}),
+			[]e{[]int{1, 2}, []int{2, 1}, []int{1, 1}},
+			[]e{[]int{2, 3}, []int{1}, []int{1, 2, 3}},
+		},
+		{"test JSONEq", gomock.JSONEq(`{"b": [true, null], "a": 1}`),
+			[]e{`{"a":1,"b":[true,null]}`, []byte(`{"b": [true, null], "a": 1.0}`), json.RawMessage(`{"a":1,"b":[true,null]}`)},
+			[]e{`{"a":1,"b":[null,true]}`, `{"a":"1","b":[true,null]}`, `{"a":1}`, `{"a":1,`, "", map[string]int{"a": 1}, nil},
+		},
+		{"test MarshalsTo", gomock.MarshalsTo(`{"Breed": "pug", "Name": "Fido"}`),
+			[]e{Dog{Breed: "pug", Name: "Fido"}, map[string]string{"Name": "Fido", "Breed": "pug"}},
+			[]e{Dog{Breed: "pug", Name: "Rex"}, Dog{}, nil, make(chan int)},
+		},
+		{"test Implements", gomock.Implements((*io.Reader)(nil)),
+			[]e{strings.NewReader("x"), new(bytes.Buffer), io.Reader(strings.NewReader("x"))},
+			[]e{nil, io.Reader(nil), "x", bytes.Buffer{}, 4},
+		},
+		{"test Implements error", gomock.Implements((*error)(nil)),
+			[]e{io.EOF, &codeError{}},
+			[]e{nil, "EOF", codeError{}, &Dog{}},
+		},
+		{"test NonNilPtr", gomock.NonNilPtr(gomock.Eq(4)),
+			[]e{intPtr(4)},
+			[]e{nil, (*int)(nil), intPtr(5), 4, new(string)},
+		},
+		{"test SliceInDelta", gomock.SliceInDelta([]float64{1, 2.5, -3}, 0.01),
+			[]e{
+				[]float64{1, 2.5, -3},
+				[]float64{1.005, 2.499, -3.01},
+				[]float32{1.001, 2.5, -2.995},
+			},
+			[]e{
+				[]float64{1, 2.52, -3},       // one element off
+				[]float64{1, 2.5},            // too short
+				[]float64{1, 2.5, -3, 4},     // too long
+				[]float64{1, math.NaN(), -3}, // NaN is never within delta
+				[]int{1, 2, -3},
+				[3]float64{1, 2.5, -3},
+				nil,
+			},
+		},
+		{"test NotEmpty", gomock.NotEmpty(),
+			[]e{[]int{1}, "a", map[string]int{"a": 1}, [1]string{"a"}},
+			[]e{nil, []int(nil), "", map[string]int{}, 42, false},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -61,6 +225,1059 @@ func TestMatchers(t *testing.T) {
 	}
 }
 
+func TestEqMatchesDeepEqual(t *testing.T) {
+	type myString string
+	nan := math.NaN()
+	values := []interface{}{
+		nil, 0, 1, int8(1), int64(1), uint(1), 1.0, float32(1), nan, 1i,
+		"", "a", myString("a"), true, false,
+		[]int{1}, []int(nil), map[string]int{"a": 1}, struct{ A int }{1}, intPtr(1),
+	}
+	for _, x := range values {
+		for _, y := range values {
+			if got, want := gomock.Eq(x).Matches(y), reflect.DeepEqual(x, y); got != want {
+				t.Errorf("Eq(%#v).Matches(%#v) = %v, want %v", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestEqString_Time(t *testing.T) {
+	d := 90 * time.Second
+	at := time.Date(2020, time.March, 1, 12, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		m    gomock.Matcher
+		want string
+	}{
+		{gomock.Eq(d), "is equal to 1m30s"},
+		{gomock.Eq(&d), "is equal to &1m30s"},
+		{gomock.Eq(at), "is equal to 2020-03-01 12:00:00 +0000 UTC"},
+		{gomock.Eq((*time.Time)(nil)), "is equal to <nil>"},
+	} {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+
+	if got := gomock.Eq(time.Now()).String(); strings.Contains(got, "m=") {
+		t.Errorf("String() = %q, want no monotonic clock reading", got)
+	}
+}
+
+func BenchmarkEq(b *testing.B) {
+	for _, bm := range []struct {
+		name string
+		x, y interface{}
+	}{
+		{"int", 42, 42},
+		{"string", "some string", "some string"},
+		{"struct", Dog{"pug", "Fido"}, Dog{"pug", "Fido"}},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			m := gomock.Eq(bm.x)
+			for i := 0; i < b.N; i++ {
+				m.Matches(bm.y)
+			}
+		})
+		b.Run(bm.name+"/DeepEqual", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				reflect.DeepEqual(bm.x, bm.y)
+			}
+		})
+	}
+}
+
+func TestSliceEqMatchesEq(t *testing.T) {
+	type myInts []int
+	type myString string
+	large := make([]int, 10000)
+	for i := range large {
+		large[i] = i
+	}
+	largeOther := append([]int(nil), large...)
+	largeOther[len(largeOther)-1] = -1
+
+	nan := math.NaN()
+	values := []interface{}{
+		[]int(nil), []int{}, []int{1}, []int{1, 2}, []int{2, 1}, large, largeOther,
+		myInts{1, 2}, []int8{1}, []uint16{1}, []uintptr{1}, []bool{true}, []bool{false},
+		[]float32{1}, []float64{1}, []float64{nan}, []complex128{1i}, []string{"a"},
+		[]myString{"a"}, []byte("a"), [][]int{{1}}, []interface{}{1, "a"},
+	}
+	for _, x := range values {
+		for _, y := range values {
+			if got, want := gomock.SliceEq(x).Matches(y), gomock.Eq(x).Matches(y); got != want {
+				t.Errorf("SliceEq(%v).Matches(%v) = %v, want %v", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestSliceEq_NotSlice(t *testing.T) {
+	defer func() {
+		msg, _ := recover().(string)
+		if want := "gomock: SliceEq of [1]int, which is not a slice"; msg != want {
+			t.Errorf("SliceEq panicked with %q, want %q", msg, want)
+		}
+	}()
+	gomock.SliceEq([1]int{1})
+}
+
+func TestInAnyOrder_NotSlice(t *testing.T) {
+	defer func() {
+		msg, _ := recover().(string)
+		if want := "gomock: InAnyOrder of int, which is not an array or slice"; msg != want {
+			t.Errorf("InAnyOrder panicked with %q, want %q", msg, want)
+		}
+	}()
+	gomock.InAnyOrder(1)
+}
+
+func TestInAnyOrderString(t *testing.T) {
+	if got, want := gomock.InAnyOrder([]int{1, 2}).String(), "has the same elements as [1 2], in any order"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestSliceEqString(t *testing.T) {
+	if got, want := gomock.SliceEq([]int{1, 2}).String(), "is equal to [1 2]"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := gomock.SliceEq([]int{}, gomock.NilEqualsEmpty()).String(), "is equal to [], treating nil as empty"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func BenchmarkSliceEq(b *testing.B) {
+	type myInts []int
+	x := make([]int, 100000)
+	for i := range x {
+		x[i] = i
+	}
+	for _, bm := range []struct {
+		name string
+		x, y interface{}
+	}{
+		{"ints", x, append([]int(nil), x...)},
+		{"named ints", myInts(x), append(myInts(nil), x...)},
+	} {
+		b.Run(bm.name+"/SliceEq", func(b *testing.B) {
+			m := gomock.SliceEq(bm.x)
+			for i := 0; i < b.N; i++ {
+				m.Matches(bm.y)
+			}
+		})
+		b.Run(bm.name+"/Eq", func(b *testing.B) {
+			m := gomock.Eq(bm.x)
+			for i := 0; i < b.N; i++ {
+				m.Matches(bm.y)
+			}
+		})
+	}
+}
+
+func intPtr(i int) *int { return &i }
+
+func TestAnyOfTracked(t *testing.T) {
+	var matched int
+	m := gomock.AnyOfTracked(&matched, gomock.Eq(1), gomock.Eq(2), gomock.Any())
+
+	for _, tt := range []struct {
+		x    interface{}
+		want int
+	}{
+		{1, 0},
+		{2, 1},
+		{"foo", 2},
+		{2, 1},
+	} {
+		if !m.Matches(tt.x) {
+			t.Errorf("AnyOfTracked should match %v", tt.x)
+		}
+		if matched != tt.want {
+			t.Errorf("AnyOfTracked matching %v recorded index %d, want %d", tt.x, matched, tt.want)
+		}
+	}
+
+	m = gomock.AnyOfTracked(&matched, gomock.Eq(1))
+	if m.Matches(3) {
+		t.Errorf("AnyOfTracked should not match 3")
+	}
+	if matched != 1 {
+		t.Errorf("AnyOfTracked matching nothing changed the recorded index to %d, want 1", matched)
+	}
+
+	if got, want := m.String(), "is equal to 1"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestLenMatchesString(t *testing.T) {
+	if got, want := gomock.LenMatches(gomock.Between(1, 3)).String(), "has length in [1, 3]"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestMapKeys_Invalid(t *testing.T) {
+	type withMap struct {
+		m map[string]int
+	}
+	for _, key := range []interface{}{[]string{"a"}, withMap{}} {
+		func() {
+			defer func() {
+				msg, _ := recover().(string)
+				if want := "gomock: invalid key"; !strings.HasPrefix(msg, want) {
+					t.Errorf("MapKeys(%#v) panicked with %q, want a message starting with %q", key, msg, want)
+				}
+			}()
+			gomock.MapKeys("a", key)
+		}()
+	}
+}
+
+func TestMapKeysString(t *testing.T) {
+	if got, want := gomock.MapKeys("a", "b").String(), "has exactly keys [a b]"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestContainsString(t *testing.T) {
+	for _, tt := range []struct {
+		m    gomock.Matcher
+		want string
+	}{
+		{gomock.Contains("token"), `contains substring "token", or an element or key equal to it`},
+		{gomock.Contains(2), "contains an element or key equal to 2"},
+		{gomock.Contains(gomock.Len(2)), "contains an element or key that has length 2"},
+		{gomock.ContainsSubsequence(1, gomock.Len(2)), "contains a subsequence of elements that: is equal to 1; has length 2"},
+	} {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestOneOfString(t *testing.T) {
+	for _, tt := range []struct {
+		m    gomock.Matcher
+		want string
+	}{
+		{gomock.OneOf(1, gomock.Len(2)), "is one of: is equal to 1; has length 2"},
+		{gomock.OneOf(), "is one of no values (never matches)"},
+		{gomock.Ne(5), "not(is equal to 5)"},
+	} {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+type codeError struct {
+	code int
+}
+
+func (e *codeError) Error() string { return fmt.Sprintf("code %d", e.code) }
+
+type ctxKey string
+
+func TestContextHasValue(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey("user"), "alice")
+	m := gomock.ContextHasValue(ctxKey("user"), "alice")
+
+	for _, tt := range []struct {
+		name    string
+		matcher gomock.Matcher
+		x       interface{}
+		want    bool
+	}{
+		{"present", m, ctx, true},
+		{"present in a parent", m, context.WithValue(ctx, ctxKey("other"), 1), true},
+		{"present and matched", gomock.ContextHasValue(ctxKey("user"), gomock.Regexp("^a")), ctx, true},
+		{"wrong value", gomock.ContextHasValue(ctxKey("user"), "bob"), ctx, false},
+		{"absent", gomock.ContextHasValue(ctxKey("id"), gomock.Any()), ctx, false},
+		{"key of another type", gomock.ContextHasValue("user", "alice"), ctx, false},
+		{"not a context", m, "alice", false},
+		{"nil", m, nil, false},
+	} {
+		if got := tt.matcher.Matches(tt.x); got != tt.want {
+			t.Errorf("%s: %v.Matches(%v) = %v, want %v", tt.name, tt.matcher, tt.x, got, tt.want)
+		}
+	}
+
+	if got, want := m.String(), "is a context whose value for key user is equal to alice"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestFieldByTag(t *testing.T) {
+	type owner struct {
+		Name   string `json:"name,omitempty"`
+		ID     int    `json:"id" db:"owner_id"`
+		secret string `db:"secret"`
+	}
+	o := owner{Name: "Fido", ID: 7, secret: "s"}
+
+	for _, tt := range []struct {
+		matcher gomock.Matcher
+		x       interface{}
+		want    bool
+	}{
+		{gomock.FieldByTag("json", "name", gomock.Eq("Fido")), o, true},
+		{gomock.FieldByTag("json", "name", gomock.Eq("Fido")), &o, true},
+		{gomock.FieldByTag("json", "name,omitempty", gomock.Eq("Fido")), o, true},
+		{gomock.FieldByTag("json", "name", gomock.Eq("Rex")), o, false},
+		{gomock.FieldByTag("json", "id", gomock.Eq(7)), o, true},
+		{gomock.FieldByTag("db", "owner_id", gomock.Eq(7)), o, true},
+		{gomock.FieldByTag("db", "id", gomock.Any()), o, false},
+		{gomock.FieldByTag("json", "missing", gomock.Any()), o, false},
+		{gomock.FieldByTag("db", "secret", gomock.Any()), o, false},
+		{gomock.FieldByTag("json", "name", gomock.Any()), (*owner)(nil), false},
+		{gomock.FieldByTag("json", "name", gomock.Any()), "Fido", false},
+		{gomock.FieldByTag("json", "name", gomock.Any()), nil, false},
+	} {
+		if got := tt.matcher.Matches(tt.x); got != tt.want {
+			t.Errorf("%v.Matches(%#v) = %v, want %v", tt.matcher, tt.x, got, tt.want)
+		}
+	}
+
+	if got, want := gomock.FieldByTag("json", "name", gomock.Eq("Fido")).String(), `has field tagged json:"name" that is equal to Fido`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestFieldsMatcher(t *testing.T) {
+	type audit struct {
+		CreatedAt time.Time
+	}
+	type user struct {
+		Name string
+		*audit
+		password string
+	}
+	u := user{Name: "x", audit: &audit{CreatedAt: time.Now()}, password: "p"}
+	m := gomock.FieldsMatcher(map[string]gomock.Matcher{
+		"Name":      gomock.Eq("x"),
+		"CreatedAt": gomock.Any(),
+	})
+
+	for _, tt := range []struct {
+		matcher gomock.Matcher
+		x       interface{}
+		want    bool
+	}{
+		{m, u, true},
+		{m, &u, true},
+		{m, user{Name: "y", audit: &audit{}}, false},
+		{m, user{Name: "x"}, false}, // CreatedAt is promoted through a nil pointer
+		{m, (*user)(nil), false},
+		{m, audit{}, false},
+		{m, "x", false},
+		{m, nil, false},
+		{gomock.FieldsMatcher(map[string]gomock.Matcher{"Missing": gomock.Any()}), u, false},
+		{gomock.FieldsMatcher(map[string]gomock.Matcher{"password": gomock.Any()}), u, false},
+		{gomock.FieldsMatcher(nil), u, true},
+	} {
+		if got := tt.matcher.Matches(tt.x); got != tt.want {
+			t.Errorf("%v.Matches(%#v) = %v, want %v", tt.matcher, tt.x, got, tt.want)
+		}
+	}
+
+	if got, want := m.String(), "has fields: CreatedAt is anything, Name is equal to x"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestEqField(t *testing.T) {
+	type address struct {
+		City string
+	}
+	type fixture struct {
+		ID    int
+		Tags  []string
+		Owner Dog
+		*address
+		token string
+	}
+	f := fixture{ID: 42, Tags: []string{"a", "b"}, Owner: Dog{Name: "Fido"}, address: &address{City: "Oslo"}, token: "t"}
+
+	for _, tt := range []struct {
+		matcher gomock.Matcher
+		x       interface{}
+		want    bool
+	}{
+		{gomock.EqField(f, "ID"), 42, true},
+		{gomock.EqField(&f, "ID"), 42, true},
+		{gomock.EqField(f, "ID"), 43, false},
+		{gomock.EqField(f, "ID"), int64(42), false},
+		{gomock.EqField(f, "Tags"), []string{"a", "b"}, true},
+		{gomock.EqField(f, "Tags"), []string{"a"}, false},
+		{gomock.EqField(f, "Owner"), Dog{Name: "Fido"}, true},
+		{gomock.EqField(f, "City"), "Oslo", true},
+		{gomock.EqField(f, "ID"), f, false},
+		{gomock.EqField(f, "ID"), nil, false},
+	} {
+		if got := tt.matcher.Matches(tt.x); got != tt.want {
+			t.Errorf("%v.Matches(%#v) = %v, want %v", tt.matcher, tt.x, got, tt.want)
+		}
+	}
+
+	if got, want := gomock.EqField(f, "ID").String(), "is equal to 42 (field ID of gomock_test.fixture)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestEqField_Invalid(t *testing.T) {
+	type fixture struct {
+		ID int
+		*Dog
+		token string
+	}
+	for _, tt := range []struct {
+		expected interface{}
+		field    string
+	}{
+		{42, "ID"},
+		{(*fixture)(nil), "ID"},
+		{nil, "ID"},
+		{fixture{}, "Missing"},
+		{fixture{}, "token"},
+		{fixture{}, "Name"}, // promoted through a nil pointer
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("EqField(%#v, %q) did not panic", tt.expected, tt.field)
+				}
+			}()
+			gomock.EqField(tt.expected, tt.field)
+		}()
+	}
+}
+
+func TestGobRoundTrips(t *testing.T) {
+	type callback struct {
+		Name string
+		Fn   func()
+	}
+	type e interface{}
+
+	m := gomock.GobRoundTrips()
+	for _, x := range []e{
+		Dog{Breed: "pug", Name: "Fido"},
+		&Dog{Breed: "pug", Name: "Fido"},
+		map[string][]int{"a": {1, 2}},
+		42,
+	} {
+		if !m.Matches(x) {
+			t.Errorf("GobRoundTrips().Matches(%#v) = false, want true", x)
+		}
+	}
+	for _, x := range []e{
+		callback{Name: "cb", Fn: func() {}}, // Fn is not transmitted
+		func() {},                           // fails to encode
+		make(chan int),
+		[]int{}, // decoded as nil
+		nil,
+	} {
+		if m.Matches(x) {
+			t.Errorf("GobRoundTrips().Matches(%#v) = true, want false", x)
+		}
+	}
+
+	if got, want := m.String(), "round-trips through encoding/gob"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+// A node can refer back to itself.
+type node struct {
+	Val      int
+	Next     *node
+	Children []*node
+}
+
+func cyclicNode(val int) *node {
+	n := &node{Val: val}
+	n.Next = n
+	n.Children = []*node{{Val: val + 1, Next: n}}
+	return n
+}
+
+func TestMatchersOnCyclicValues(t *testing.T) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		if !gomock.Eq(cyclicNode(1)).Matches(cyclicNode(1)) {
+			t.Error("Eq should match an equal cyclic value")
+		}
+		if gomock.Eq(cyclicNode(1)).Matches(cyclicNode(2)) {
+			t.Error("Eq should not match a different cyclic value")
+		}
+		if gomock.GobRoundTrips().Matches(cyclicNode(1)) {
+			t.Error("GobRoundTrips should not match a cyclic value")
+		}
+		if !gomock.GobRoundTrips().Matches(&node{Val: 1, Next: &node{Val: 2}}) {
+			t.Error("GobRoundTrips should match an acyclic value")
+		}
+		if gomock.MarshalsTo(`{"Val": 1}`).Matches(cyclicNode(1)) {
+			t.Error("MarshalsTo should not match a cyclic value")
+		}
+		if !gomock.FieldByTag("json", "x", gomock.Any()).Matches(struct {
+			X *node `json:"x"`
+		}{cyclicNode(1)}) {
+			t.Error("FieldByTag should match a field holding a cyclic value")
+		}
+		_ = gomock.Eq(cyclicNode(1)).String()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("matching cyclic values did not terminate")
+	}
+}
+
+func TestBetween_InvalidRange(t *testing.T) {
+	for _, tt := range []struct {
+		low, high interface{}
+		want      string
+	}{
+		{10, 1, "low is greater than high"},
+		{1.5, uint(1), "low is greater than high"},
+		{"a", "b", "bounds must be numbers"},
+		{0, nil, "bounds must be numbers"},
+		{math.NaN(), 1, "bounds must be numbers"},
+	} {
+		func() {
+			defer func() {
+				r := recover()
+				if msg, _ := r.(string); !strings.Contains(msg, tt.want) {
+					t.Errorf("Between(%v, %v) panicked with %v, want %q", tt.low, tt.high, r, tt.want)
+				}
+			}()
+			gomock.Between(tt.low, tt.high)
+		}()
+	}
+}
+
+func TestCompareMatchers(t *testing.T) {
+	for _, tt := range []struct {
+		matcher gomock.Matcher
+		yes, no []interface{}
+	}{
+		{gomock.GreaterThan(int32(5)),
+			[]interface{}{6, uint8(6), 5.5, float32(100)},
+			[]interface{}{5, int64(-6), 4.9, math.NaN(), "6", nil}},
+		{gomock.LessThan(uint8(5)),
+			[]interface{}{-1, int8(-128), 4.9, uint64(4)},
+			[]interface{}{5, uint(5), 5.1, uint64(math.MaxUint64), math.NaN(), "4"}},
+		{gomock.GreaterThanOrEqual(5),
+			[]interface{}{5, uint(5), 5.0, int64(math.MaxInt64)},
+			[]interface{}{4, 4.9, int8(-5), "5", struct{}{}}},
+		{gomock.LessThanOrEqual(-1.5),
+			[]interface{}{-2, -1.5, float32(-1.5), int64(math.MinInt64)},
+			[]interface{}{-1, uint(0), "-2", nil}},
+	} {
+		for _, x := range tt.yes {
+			if !tt.matcher.Matches(x) {
+				t.Errorf("%v.Matches(%#v) = false, want true", tt.matcher, x)
+			}
+		}
+		for _, x := range tt.no {
+			if tt.matcher.Matches(x) {
+				t.Errorf("%v.Matches(%#v) = true, want false", tt.matcher, x)
+			}
+		}
+	}
+}
+
+func TestCompareMatchers_InvalidBound(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		new  func(interface{}) gomock.Matcher
+		x    interface{}
+	}{
+		{"GreaterThan", gomock.GreaterThan, "5"},
+		{"LessThan", gomock.LessThan, nil},
+		{"GreaterThanOrEqual", gomock.GreaterThanOrEqual, math.NaN()},
+		{"LessThanOrEqual", gomock.LessThanOrEqual, []int{5}},
+	} {
+		func() {
+			defer func() {
+				msg, _ := recover().(string)
+				if want := "gomock: invalid bound " + fmt.Sprint(tt.x) + " for " + tt.name; !strings.HasPrefix(msg, want) {
+					t.Errorf("%s(%v) panicked with %q, want prefix %q", tt.name, tt.x, msg, want)
+				}
+			}()
+			tt.new(tt.x)
+		}()
+	}
+}
+
+func TestCompareMatchersString(t *testing.T) {
+	for _, tt := range []struct {
+		m    gomock.Matcher
+		want string
+	}{
+		{gomock.GreaterThan(5), "greater than 5"},
+		{gomock.LessThan(5), "less than 5"},
+		{gomock.GreaterThanOrEqual(5), "greater than or equal to 5"},
+		{gomock.LessThanOrEqual(1.5), "less than or equal to 1.5"},
+	} {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestJSONEq_Invalid(t *testing.T) {
+	defer func() {
+		msg, _ := recover().(string)
+		if want := `gomock: invalid JSON "{\"a\":" for JSONEq`; !strings.HasPrefix(msg, want) {
+			t.Errorf("JSONEq panicked with %q, want prefix %q", msg, want)
+		}
+	}()
+	gomock.JSONEq(`{"a":`)
+}
+
+func TestMarshalsTo_Invalid(t *testing.T) {
+	defer func() {
+		msg, _ := recover().(string)
+		if want := `gomock: invalid JSON "{\"a\":" for MarshalsTo`; !strings.HasPrefix(msg, want) {
+			t.Errorf("MarshalsTo panicked with %q, want prefix %q", msg, want)
+		}
+	}()
+	gomock.MarshalsTo(`{"a":`)
+}
+
+func TestJSONEqString(t *testing.T) {
+	if got, want := gomock.JSONEq(`{ "b": [true], "a": 1 }`).String(), `is JSON equal to {"a":1,"b":[true]}`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestRegexp_Invalid(t *testing.T) {
+	defer func() {
+		msg, _ := recover().(string)
+		if want := `gomock: invalid pattern "^foo(" for Regexp`; !strings.HasPrefix(msg, want) {
+			t.Errorf("Regexp panicked with %q, want prefix %q", msg, want)
+		}
+	}()
+	gomock.Regexp(`^foo(`)
+}
+
+func TestRegexpString(t *testing.T) {
+	if got, want := gomock.Regexp(`^foo.*`).String(), `matches regexp "^foo.*"`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestRegexCapture_Invalid(t *testing.T) {
+	for _, tt := range []struct {
+		pattern string
+		group   int
+		want    string
+	}{
+		{`user/(\d+)`, 2, `gomock: pattern "user/(\\d+)" for RegexCapture has no group 2`},
+		{`user/(\d+)`, -1, `gomock: pattern "user/(\\d+)" for RegexCapture has no group -1`},
+		{`user/(\d+`, 1, `gomock: invalid pattern "user/(\\d+" for RegexCapture`},
+	} {
+		func() {
+			defer func() {
+				if msg, _ := recover().(string); !strings.HasPrefix(msg, tt.want) {
+					t.Errorf("RegexCapture(%q, %d) panicked with %q, want prefix %q", tt.pattern, tt.group, msg, tt.want)
+				}
+			}()
+			gomock.RegexCapture(tt.pattern, tt.group, gomock.Any())
+		}()
+	}
+}
+
+func TestRegexCaptureString(t *testing.T) {
+	if got, want := gomock.RegexCapture(`id=(\d+)`, 1, gomock.Eq("42")).String(), `matches "id=(\\d+)" with group 1 that is equal to 42`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestBetweenString(t *testing.T) {
+	if got, want := gomock.Between(1, 2.5).String(), "in [1, 2.5]"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestEqFoldString(t *testing.T) {
+	if got, want := gomock.EqFold("Content-Type").String(), `equals (case-insensitive) "Content-Type"`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestApproxEq(t *testing.T) {
+	inf := math.Inf(1)
+	for _, tt := range []struct {
+		matcher gomock.Matcher
+		yes, no []interface{}
+	}{
+		{gomock.ApproxEq(3.14, 0.01),
+			[]interface{}{3.14, 3.1415, 3.135, float32(3.141)},
+			[]interface{}{3.2, float32(3.2), math.NaN(), inf, -inf, 3, "3.14", nil}},
+		{gomock.ApproxEq(inf, 1),
+			[]interface{}{inf, float32(inf)},
+			[]interface{}{-inf, math.MaxFloat64, math.NaN()}},
+		{gomock.ApproxEq(0, inf),
+			[]interface{}{0.0, -math.MaxFloat64, inf, -inf},
+			[]interface{}{math.NaN()}},
+		{gomock.ApproxEq(math.NaN(), inf),
+			nil,
+			[]interface{}{0.0, inf, math.NaN()}},
+	} {
+		for _, x := range tt.yes {
+			if !tt.matcher.Matches(x) {
+				t.Errorf("%v.Matches(%#v) = false, want true", tt.matcher, x)
+			}
+		}
+		for _, x := range tt.no {
+			if tt.matcher.Matches(x) {
+				t.Errorf("%v.Matches(%#v) = true, want false", tt.matcher, x)
+			}
+		}
+	}
+
+	for _, tolerance := range []float64{-0.01, math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("ApproxEq(1, %v) did not panic", tolerance)
+				}
+			}()
+			gomock.ApproxEq(1, tolerance)
+		}()
+	}
+
+	if got, want := gomock.ApproxEq(3.14, 0.01).String(), "is within 0.01 of 3.14"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestSliceInDelta_Invalid(t *testing.T) {
+	for _, delta := range []float64{-0.01, math.NaN()} {
+		func() {
+			defer func() {
+				msg, _ := recover().(string)
+				if want := "gomock: invalid delta"; !strings.HasPrefix(msg, want) {
+					t.Errorf("SliceInDelta(..., %v) panicked with %q, want a message starting with %q", delta, msg, want)
+				}
+			}()
+			gomock.SliceInDelta([]float64{1}, delta)
+		}()
+	}
+}
+
+func TestSliceInDeltaString(t *testing.T) {
+	if got, want := gomock.SliceInDelta([]float64{1, 2.5}, 0.01).String(), "is within 0.01 of [1 2.5]"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestNonNilPtrString(t *testing.T) {
+	if got, want := gomock.NonNilPtr(gomock.Eq(4)).String(), "non-nil pointer to is equal to 4"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestPtrEqWith(t *testing.T) {
+	var got []interface{}
+	sameName := func(a, b interface{}) bool {
+		got = append(got, a, b)
+		return a.(Dog).Name == b.(Dog).Name
+	}
+	m := gomock.PtrEqWith(Dog{Name: "Fido", Breed: "pug"}, sameName)
+
+	if !m.Matches(&Dog{Name: "Fido", Breed: "collie"}) {
+		t.Error("pointer to a dog with the same name but another breed did not match")
+	}
+	if want := []interface{}{Dog{Name: "Fido", Breed: "pug"}, Dog{Name: "Fido", Breed: "collie"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("comparison called with %v, want %v", got, want)
+	}
+	for _, x := range []interface{}{&Dog{Name: "Rex", Breed: "pug"}, Dog{Name: "Fido"}, (*Dog)(nil), nil} {
+		if m.Matches(x) {
+			t.Errorf("%v matched %#v", m, x)
+		}
+	}
+
+	if got, want := m.String(), "non-nil pointer to is equal to {pug Fido} by a custom comparison"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("PtrEqWith with a nil comparison did not panic")
+		}
+	}()
+	gomock.PtrEqWith(Dog{}, nil)
+}
+
+func TestSame(t *testing.T) {
+	ch := make(chan int)
+	dog := &Dog{Name: "Fido"}
+	m := map[string]int{"a": 1}
+	for _, tc := range []struct {
+		x       interface{}
+		yes, no []interface{}
+	}{
+		{ch, []interface{}{ch, (<-chan int)(ch), (chan<- int)(ch)}, []interface{}{make(chan int), make(chan int64), nil}},
+		{(<-chan int)(ch), []interface{}{ch}, []interface{}{make(<-chan int)}},
+		{dog, []interface{}{dog}, []interface{}{&Dog{Name: "Fido"}, *dog, (*Dog)(nil)}},
+		{m, []interface{}{m}, []interface{}{map[string]int{"a": 1}, map[string]int64{}}},
+	} {
+		matcher := gomock.Same(tc.x)
+		for _, x := range tc.yes {
+			if !matcher.Matches(x) {
+				t.Errorf("Same(%v).Matches(%v) = false, want true", tc.x, x)
+			}
+		}
+		for _, x := range tc.no {
+			if matcher.Matches(x) {
+				t.Errorf("Same(%v).Matches(%v) = true, want false", tc.x, x)
+			}
+		}
+	}
+
+	if got, want := gomock.Same(ch).String(), fmt.Sprintf("is the same chan int as %v", ch); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestSame_Invalid(t *testing.T) {
+	defer func() {
+		msg, _ := recover().(string)
+		if want := "gomock: invalid value [1] of type []int for Same: it must be a pointer, channel or map"; msg != want {
+			t.Errorf("Same panicked with %q, want %q", msg, want)
+		}
+	}()
+	gomock.Same([]int{1})
+}
+
+func TestBigEq(t *testing.T) {
+	hundred, _ := new(big.Int).SetString("100", 10)
+	half := big.NewRat(1, 2)
+	for _, tc := range []struct {
+		expected interface{}
+		yes, no  []interface{}
+	}{
+		{
+			big.NewInt(100),
+			[]interface{}{hundred, new(big.Int).Exp(big.NewInt(10), big.NewInt(2), nil), new(big.Int).Mul(big.NewInt(-4), big.NewInt(-25))},
+			[]interface{}{big.NewInt(101), big.NewRat(100, 1), *big.NewInt(100), 100, (*big.Int)(nil), nil},
+		},
+		{
+			half,
+			[]interface{}{big.NewRat(2, 4), new(big.Rat).SetFloat64(0.5), new(big.Rat).Quo(big.NewRat(3, 1), big.NewRat(6, 1))},
+			[]interface{}{big.NewRat(1, 3), big.NewFloat(0.5), 0.5},
+		},
+		{
+			big.NewFloat(0.25),
+			[]interface{}{new(big.Float).SetPrec(200).SetFloat64(0.25), new(big.Float).Quo(big.NewFloat(1), big.NewFloat(4))},
+			[]interface{}{big.NewFloat(0.3), half},
+		},
+		{
+			(*big.Int)(nil),
+			[]interface{}{(*big.Int)(nil)},
+			[]interface{}{big.NewInt(0), nil},
+		},
+	} {
+		m := gomock.BigEq(tc.expected)
+		for _, x := range tc.yes {
+			if !m.Matches(x) {
+				t.Errorf("BigEq(%v).Matches(%v) = false, want true", tc.expected, x)
+			}
+		}
+		for _, x := range tc.no {
+			if m.Matches(x) {
+				t.Errorf("BigEq(%v).Matches(%v) = true, want false", tc.expected, x)
+			}
+		}
+	}
+
+	if got, want := gomock.BigEq(half).String(), "is numerically equal to 1/2"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestBigEq_Invalid(t *testing.T) {
+	for _, expected := range []interface{}{*big.NewInt(1), 1, time.Now(), nil} {
+		func() {
+			defer func() {
+				msg, _ := recover().(string)
+				if want := "gomock: invalid value"; !strings.HasPrefix(msg, want) {
+					t.Errorf("BigEq(%v) panicked with %q, want a message starting with %q", expected, msg, want)
+				}
+			}()
+			gomock.BigEq(expected)
+		}()
+	}
+}
+
+type cachedUser struct {
+	Name    string
+	Tags    []string
+	Friends []cachedUser
+	Manager *cachedUser
+	Extra   map[string]cachedUser
+	cache   []byte
+}
+
+func TestEqExported(t *testing.T) {
+	boss := &cachedUser{Name: "boss", cache: []byte{1}}
+	expected := cachedUser{
+		Name:    "a",
+		Tags:    []string{"x"},
+		Friends: []cachedUser{{Name: "b", cache: []byte{2}}},
+		Manager: boss,
+		Extra:   map[string]cachedUser{"c": {Name: "c"}},
+		cache:   []byte{3},
+	}
+	m := gomock.EqExported(expected)
+
+	same := cachedUser{
+		Name:    "a",
+		Tags:    []string{"x"},
+		Friends: []cachedUser{{Name: "b", cache: []byte{9}}},
+		Manager: &cachedUser{Name: "boss"},
+		Extra:   map[string]cachedUser{"c": {Name: "c", cache: []byte{9}}},
+	}
+	if !m.Matches(same) {
+		t.Errorf("%v did not match %+v, whose unexported fields alone differ", m, same)
+	}
+	if !gomock.EqExported(&expected).Matches(&same) {
+		t.Error("pointers to values whose unexported fields alone differ did not match")
+	}
+
+	for _, x := range []interface{}{
+		cachedUser{Name: "b"},
+		cachedUser{Name: "a", Tags: []string{"x"}, Friends: []cachedUser{{Name: "c"}}, Manager: boss, Extra: expected.Extra},
+		cachedUser{Name: "a", Tags: []string{"x"}, Friends: expected.Friends, Manager: &cachedUser{Name: "other"}, Extra: expected.Extra},
+		cachedUser{Name: "a", Tags: []string{"x"}, Friends: expected.Friends, Manager: boss, Extra: map[string]cachedUser{"d": {Name: "c"}}},
+		cachedUser{Name: "a", Tags: nil, Friends: expected.Friends, Manager: boss, Extra: expected.Extra},
+		&expected,
+		nil,
+	} {
+		if m.Matches(x) {
+			t.Errorf("%v matched %+v", m, x)
+		}
+	}
+
+	// Cyclic values terminate.
+	a := &cachedUser{Name: "a", cache: []byte{1}}
+	a.Manager = a
+	b := &cachedUser{Name: "a"}
+	b.Manager = b
+	if !gomock.EqExported(a).Matches(b) {
+		t.Error("equal cyclic values did not match")
+	}
+	if gomock.EqExported(a).Matches(&cachedUser{Name: "a", Manager: &cachedUser{Name: "b"}}) {
+		t.Error("different values matched")
+	}
+
+	if got, want := gomock.EqExported(cachedUser{Name: "a"}).String(), "has exported fields equal to those of {a [] [] <nil> map[] []}"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestCaptureString(t *testing.T) {
+	var err error
+	if got, want := gomock.Capture(&err).String(), "captures argument of type error"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := gomock.NewCaptor().String(), "captures argument"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestCapture_Matches(t *testing.T) {
+	var err error
+	m := gomock.Capture(&err)
+	for _, x := range []interface{}{io.EOF, nil} {
+		if !m.Matches(x) {
+			t.Errorf("%v did not match %v", m, x)
+		}
+	}
+	if err != nil {
+		t.Errorf("Matches alone captured %v", err)
+	}
+}
+
+func TestCapture_TypeMismatch(t *testing.T) {
+	var err error
+	var n int
+	for _, tt := range []struct {
+		matcher gomock.Matcher
+		x       interface{}
+		want    string
+	}{
+		{gomock.Capture(&err), "EOF", "gomock: Capture cannot store an argument of type string into a destination of type error"},
+		{gomock.Capture(&n), int64(1), "gomock: Capture cannot store an argument of type int64 into a destination of type int"},
+		{gomock.Capture(&n), nil, "gomock: Capture cannot store nil into a destination of type int"},
+	} {
+		func() {
+			defer func() {
+				if msg, _ := recover().(string); msg != tt.want {
+					t.Errorf("%v.Matches(%#v) panicked with %q, want %q", tt.matcher, tt.x, msg, tt.want)
+				}
+			}()
+			tt.matcher.Matches(tt.x)
+		}()
+	}
+	if err != nil || n != 0 {
+		t.Errorf("mismatched arguments captured %v, %v", err, n)
+	}
+}
+
+func TestCapture_Invalid(t *testing.T) {
+	for _, dest := range []interface{}{1, (*int)(nil), nil} {
+		func() {
+			defer func() {
+				msg, _ := recover().(string)
+				if want := "gomock: invalid destination"; !strings.HasPrefix(msg, want) {
+					t.Errorf("Capture(%v) panicked with %q, want a message starting with %q", dest, msg, want)
+				}
+			}()
+			gomock.Capture(dest)
+		}()
+	}
+}
+
+func TestCaptor_ValueOutOfRange(t *testing.T) {
+	defer func() {
+		msg, _ := recover().(string)
+		if want := "gomock: Captor.Value(0) called with 0 captured values"; msg != want {
+			t.Errorf("Value panicked with %q, want %q", msg, want)
+		}
+	}()
+	gomock.NewCaptor().Value(0)
+}
+
+func TestImplementsString(t *testing.T) {
+	if got, want := gomock.Implements((*io.ReadCloser)(nil)).String(), "implements io.ReadCloser"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestImplements_Invalid(t *testing.T) {
+	var r io.Reader
+	for _, x := range []interface{}{nil, r, new(int), "io.Reader", (**io.Reader)(nil)} {
+		func() {
+			defer func() {
+				msg, _ := recover().(string)
+				if want := "gomock: invalid value"; !strings.HasPrefix(msg, want) {
+					t.Errorf("Implements(%#v) panicked with %q, want a message starting with %q", x, msg, want)
+				}
+			}()
+			gomock.Implements(x)
+		}()
+	}
+}
+
 // A more thorough test of notMatcher
 func TestNotMatcher(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -0,0 +1,118 @@
+// Copyright 2010 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+)
+
+// LoadReturnsFromJSON sets up mock, a generated mock, to return the values in
+// the JSON fixture at path, recording the expected calls with recorder, the
+// mock's recorder. It is called by the LoadFromJSON method that mockgen
+// generates with -json_stubs, and should not be called by user code.
+//
+// The fixture is an object that maps method names to arrays holding one JSON
+// value per result of the method, which is unmarshaled into a value of the
+// result's type. An error result is either null, for a nil error, or a string,
+// for an error with that message; other interface results must be null unless
+// they are empty interfaces. For each method in the fixture, a call with any
+// arguments is expected any number of times, and returns the values. For
+// example:
+//
+//   {
+//     "Get": ["value", null],
+//     "Keys": [["a", "b"]],
+//     "Delete": ["not found"]
+//   }
+func LoadReturnsFromJSON(mock, recorder interface{}, path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var fixture map[string][]json.RawMessage
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return fmt.Errorf("%v: %v", path, err)
+	}
+
+	// Set up the methods in a predictable order.
+	methods := make([]string, 0, len(fixture))
+	for method := range fixture {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	for _, method := range methods {
+		m := reflect.ValueOf(mock).MethodByName(method)
+		r := reflect.ValueOf(recorder).MethodByName(method)
+		if !m.IsValid() || !r.IsValid() {
+			return fmt.Errorf("%v: %T has no method %v", path, mock, method)
+		}
+		mt := m.Type()
+
+		raws := fixture[method]
+		if len(raws) != mt.NumOut() {
+			return fmt.Errorf("%v: method %v has %d results, but the fixture has %d",
+				path, method, mt.NumOut(), len(raws))
+		}
+		rets := make([]interface{}, len(raws))
+		for i, raw := range raws {
+			ret, err := unmarshalResult(raw, mt.Out(i))
+			if err != nil {
+				return fmt.Errorf("%v: result %d of method %v: %v", path, i, method, err)
+			}
+			rets[i] = ret
+		}
+
+		args := make([]reflect.Value, r.Type().NumIn())
+		for i := range args {
+			args[i] = reflect.ValueOf(Any())
+		}
+		call := r.Call(args)[0].Interface().(*Call)
+		call.Return(rets...).AnyTimes()
+	}
+	return nil
+}
+
+// unmarshalResult unmarshals raw into a value of type t.
+func unmarshalResult(raw json.RawMessage, t reflect.Type) (interface{}, error) {
+	isNull := string(raw) == "null"
+	switch {
+	case t == errorType:
+		if isNull {
+			return nil, nil
+		}
+		var msg string
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			return nil, fmt.Errorf("an error must be null or a string: %v", err)
+		}
+		return errors.New(msg), nil
+	case t.Kind() == reflect.Interface && t.NumMethod() > 0:
+		if isNull {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cannot unmarshal into interface type %v, which must be null", t)
+	}
+
+	v := reflect.New(t)
+	if err := json.Unmarshal(raw, v.Interface()); err != nil {
+		return nil, err
+	}
+	return v.Elem().Interface(), nil
+}
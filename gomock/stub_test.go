@@ -0,0 +1,103 @@
+// Copyright 2010 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type stubMock struct{}
+
+func (stubMock) Get(key string) (int, error) { return 0, nil }
+
+type stubRecorder struct{}
+
+func (stubRecorder) Get(key interface{}) *Call { return nil }
+
+func TestUnmarshalResult(t *testing.T) {
+	for _, tt := range []struct {
+		raw  string
+		typ  reflect.Type
+		want interface{}
+	}{
+		{`3`, reflect.TypeOf(0), 3},
+		{`"x"`, reflect.TypeOf(""), "x"},
+		{`["a", "b"]`, reflect.TypeOf([]string{}), []string{"a", "b"}},
+		{`{"a": 1}`, reflect.TypeOf(map[string]int{}), map[string]int{"a": 1}},
+		{`{"a": 1}`, reflect.TypeOf((*interface{})(nil)).Elem(), map[string]interface{}{"a": 1.0}},
+		{`null`, errorType, nil},
+		{`"boom"`, errorType, errors.New("boom")},
+		{`null`, reflect.TypeOf((*fmt.Stringer)(nil)).Elem(), nil},
+	} {
+		got, err := unmarshalResult([]byte(tt.raw), tt.typ)
+		if err != nil {
+			t.Errorf("unmarshalResult(%s, %v) error = %v", tt.raw, tt.typ, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("unmarshalResult(%s, %v) = %#v, want %#v", tt.raw, tt.typ, got, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		raw string
+		typ reflect.Type
+	}{
+		{`"x"`, reflect.TypeOf(0)},
+		{`3`, errorType},
+		{`"x"`, reflect.TypeOf((*fmt.Stringer)(nil)).Elem()},
+	} {
+		if got, err := unmarshalResult([]byte(tt.raw), tt.typ); err == nil {
+			t.Errorf("unmarshalResult(%s, %v) = %#v, want an error", tt.raw, tt.typ, got)
+		}
+	}
+}
+
+func TestLoadReturnsFromJSONErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gomock_stub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, tt := range []struct {
+		fixture, want string
+	}{
+		{`[1, 2]`, "cannot unmarshal array"},
+		{`{"Put": [null]}`, "has no method Put"},
+		{`{"Get": [1]}`, "method Get has 2 results, but the fixture has 1"},
+		{`{"Get": ["one", null]}`, "result 0 of method Get"},
+	} {
+		path := filepath.Join(dir, "fixture.json")
+		if err := ioutil.WriteFile(path, []byte(tt.fixture), 0644); err != nil {
+			t.Fatal(err)
+		}
+		err := LoadReturnsFromJSON(stubMock{}, stubRecorder{}, path)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("LoadReturnsFromJSON(%s) error = %v, want one containing %q", tt.fixture, err, tt.want)
+		}
+	}
+
+	if err := LoadReturnsFromJSON(stubMock{}, stubRecorder{}, filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("LoadReturnsFromJSON() of a missing file succeeded, want an error")
+	}
+}
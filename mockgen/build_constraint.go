@@ -0,0 +1,337 @@
+// Copyright 2010 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+// This file parses and prints build constraints. It does what little of
+// go/build/constraint mockgen needs, since that package requires Go 1.16.
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// A buildExpr is a build constraint expression, such as "linux && !cgo".
+type buildExpr interface {
+	String() string
+}
+
+type tagExpr string
+
+type notExpr struct {
+	x buildExpr
+}
+
+type andExpr struct {
+	x, y buildExpr
+}
+
+type orExpr struct {
+	x, y buildExpr
+}
+
+func (x tagExpr) String() string { return string(x) }
+
+func (x notExpr) String() string {
+	s := x.x.String()
+	switch x.x.(type) {
+	case andExpr, orExpr:
+		s = "(" + s + ")"
+	}
+	return "!" + s
+}
+
+func (x andExpr) String() string { return andArg(x.x) + " && " + andArg(x.y) }
+
+func andArg(x buildExpr) string {
+	s := x.String()
+	if _, ok := x.(orExpr); ok {
+		s = "(" + s + ")"
+	}
+	return s
+}
+
+func (x orExpr) String() string { return orArg(x.x) + " || " + orArg(x.y) }
+
+func orArg(x buildExpr) string {
+	s := x.String()
+	if _, ok := x.(andExpr); ok {
+		s = "(" + s + ")"
+	}
+	return s
+}
+
+// isGoBuild reports whether line is a //go:build constraint.
+func isGoBuild(line string) bool {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "//go:build") {
+		return false
+	}
+	line = line[len("//go:build"):]
+	return line == "" || line[0] == ' ' || line[0] == '\t'
+}
+
+// isPlusBuild reports whether line is a // +build constraint.
+func isPlusBuild(line string) bool {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "//") {
+		return false
+	}
+	line = strings.TrimSpace(line[len("//"):])
+	if !strings.HasPrefix(line, "+build") {
+		return false
+	}
+	line = line[len("+build"):]
+	return line == "" || line[0] == ' ' || line[0] == '\t'
+}
+
+// parseGoBuild parses the expression of a //go:build line, given without the
+// "//go:build" prefix, such as a -build_tag.
+func parseGoBuild(text string) (buildExpr, error) {
+	p := &buildExprParser{s: text}
+	x, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.next(); tok != "" {
+		return nil, fmt.Errorf("unexpected %q", tok)
+	}
+	return x, nil
+}
+
+// parsePlusBuild parses a // +build line. Its space-separated options are
+// ORed, and the comma-separated terms of each option are ANDed.
+func parsePlusBuild(line string) (buildExpr, error) {
+	line = strings.TrimSpace(strings.TrimSpace(line)[len("//"):])
+	fields := strings.Fields(line[len("+build"):])
+	if len(fields) == 0 {
+		return nil, errors.New("empty +build line")
+	}
+	var x buildExpr
+	for _, field := range fields {
+		var y buildExpr
+		for _, term := range strings.Split(field, ",") {
+			var z buildExpr
+			if strings.HasPrefix(term, "!") {
+				z = notExpr{tagExpr(term[1:])}
+				term = term[1:]
+			} else {
+				z = tagExpr(term)
+			}
+			if !isBuildTag(term) {
+				return nil, fmt.Errorf("invalid +build term %q", field)
+			}
+			if y == nil {
+				y = z
+			} else {
+				y = andExpr{y, z}
+			}
+		}
+		if x == nil {
+			x = y
+		} else {
+			x = orExpr{x, y}
+		}
+	}
+	return x, nil
+}
+
+func isBuildTag(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !(r == '_' || r == '.' || '0' <= r && r <= '9' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// buildExprParser is a recursive descent parser of //go:build expressions.
+type buildExprParser struct {
+	s   string // the rest of the input
+	tok string // a token read by peek but not yet by next
+}
+
+func (p *buildExprParser) peek() string {
+	if p.tok == "" {
+		p.tok = p.lex()
+	}
+	return p.tok
+}
+
+func (p *buildExprParser) next() string {
+	tok := p.peek()
+	p.tok = ""
+	return tok
+}
+
+// lex returns the next token of the input, or "" at its end.
+func (p *buildExprParser) lex() string {
+	p.s = strings.TrimLeft(p.s, " \t")
+	if p.s == "" {
+		return ""
+	}
+	n := 1
+	switch {
+	case strings.HasPrefix(p.s, "&&"), strings.HasPrefix(p.s, "||"):
+		n = 2
+	case isBuildTag(p.s[:1]):
+		for n < len(p.s) && isBuildTag(p.s[n:n+1]) {
+			n++
+		}
+	}
+	tok := p.s[:n]
+	p.s = p.s[n:]
+	return tok
+}
+
+func (p *buildExprParser) or() (buildExpr, error) {
+	x, err := p.and()
+	for err == nil && p.peek() == "||" {
+		p.next()
+		var y buildExpr
+		if y, err = p.and(); err == nil {
+			x = orExpr{x, y}
+		}
+	}
+	return x, err
+}
+
+func (p *buildExprParser) and() (buildExpr, error) {
+	x, err := p.not()
+	for err == nil && p.peek() == "&&" {
+		p.next()
+		var y buildExpr
+		if y, err = p.not(); err == nil {
+			x = andExpr{x, y}
+		}
+	}
+	return x, err
+}
+
+func (p *buildExprParser) not() (buildExpr, error) {
+	switch tok := p.next(); {
+	case tok == "!":
+		x, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{x}, nil
+	case tok == "(":
+		x, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok != ")" {
+			return nil, errors.New("missing )")
+		}
+		return x, nil
+	case isBuildTag(tok):
+		return tagExpr(tok), nil
+	case tok == "":
+		return nil, errors.New("unexpected end of expression")
+	default:
+		return nil, fmt.Errorf("unexpected %q", tok)
+	}
+}
+
+// maxPlusBuildTerms limits the size of the // +build lines of an expression,
+// which may grow exponentially with its size.
+const maxPlusBuildTerms = 100
+
+// plusBuildLines returns // +build lines equivalent to x, for Go versions
+// before 1.17. There is one line for each operand of the top-level &&s of x,
+// in disjunctive normal form, or a single line if none of them has an ||.
+func plusBuildLines(x buildExpr) ([]string, error) {
+	var split [][][]string
+	maxOr := 0
+	for _, conjunct := range splitAnd(pushNot(x, false)) {
+		or := disjunctiveForm(conjunct)
+		if len(or) > maxPlusBuildTerms {
+			return nil, errors.New("expression too complex for // +build lines")
+		}
+		if len(or) > maxOr {
+			maxOr = len(or)
+		}
+		split = append(split, or)
+	}
+	if maxOr == 1 {
+		var terms []string
+		for _, or := range split {
+			terms = append(terms, or[0]...)
+		}
+		split = [][][]string{{terms}}
+	}
+
+	lines := make([]string, len(split))
+	for i, or := range split {
+		options := make([]string, len(or))
+		for j, terms := range or {
+			options[j] = strings.Join(terms, ",")
+		}
+		lines[i] = "// +build " + strings.Join(options, " ")
+	}
+	return lines, nil
+}
+
+// pushNot returns x, negated if not is set, with all negations applied
+// directly to tags.
+func pushNot(x buildExpr, not bool) buildExpr {
+	switch x := x.(type) {
+	case notExpr:
+		return pushNot(x.x, !not)
+	case andExpr:
+		if not {
+			return orExpr{pushNot(x.x, true), pushNot(x.y, true)}
+		}
+		return andExpr{pushNot(x.x, false), pushNot(x.y, false)}
+	case orExpr:
+		if not {
+			return andExpr{pushNot(x.x, true), pushNot(x.y, true)}
+		}
+		return orExpr{pushNot(x.x, false), pushNot(x.y, false)}
+	}
+	if not {
+		return notExpr{x}
+	}
+	return x
+}
+
+func splitAnd(x buildExpr) []buildExpr {
+	if x, ok := x.(andExpr); ok {
+		return append(splitAnd(x.x), splitAnd(x.y)...)
+	}
+	return []buildExpr{x}
+}
+
+// disjunctiveForm returns x, whose negations apply directly to tags, as an OR
+// of ANDs of possibly negated tags.
+func disjunctiveForm(x buildExpr) [][]string {
+	switch x := x.(type) {
+	case orExpr:
+		return append(disjunctiveForm(x.x), disjunctiveForm(x.y)...)
+	case andExpr:
+		var terms [][]string
+		for _, a := range disjunctiveForm(x.x) {
+			for _, b := range disjunctiveForm(x.y) {
+				terms = append(terms, append(append([]string(nil), a...), b...))
+			}
+		}
+		return terms
+	}
+	return [][]string{{x.String()}}
+}
@@ -0,0 +1,65 @@
+//go:build go1.16
+// +build go1.16
+
+package main
+
+import (
+	"go/build/constraint"
+	"reflect"
+	"testing"
+)
+
+// TestBuildConstraint_MatchesStandardLibrary checks the parsing and printing
+// of build constraints against go/build/constraint where it is available.
+func TestBuildConstraint_MatchesStandardLibrary(t *testing.T) {
+	for _, src := range []string{
+		"linux",
+		"!plan9 && (mocks || test)",
+		"a && b && c || d",
+		"a || b && !(c || !d)",
+		"!(a && b)",
+		"(a || b) && (c || d)",
+		"go1.18 && !windows",
+	} {
+		want, err := constraint.Parse("//go:build " + src)
+		if err != nil {
+			t.Fatalf("constraint.Parse(%q) error: %v", src, err)
+		}
+		got, err := parseGoBuild(src)
+		if err != nil {
+			t.Errorf("parseGoBuild(%q) error: %v", src, err)
+			continue
+		}
+		if got.String() != want.String() {
+			t.Errorf("parseGoBuild(%q) = %q, want %q", src, got, want)
+		}
+		gotLines, err := plusBuildLines(got)
+		if err != nil {
+			t.Errorf("plusBuildLines(%q) error: %v", src, err)
+			continue
+		}
+		wantLines, err := constraint.PlusBuildLines(want)
+		if err != nil {
+			t.Fatalf("constraint.PlusBuildLines(%q) error: %v", src, err)
+		}
+		if !reflect.DeepEqual(gotLines, wantLines) {
+			t.Errorf("plusBuildLines(%q) = %q, want %q", src, gotLines, wantLines)
+		}
+	}
+
+	for _, line := range []string{"// +build linux darwin", "// +build linux,!cgo 386", "//+build a"} {
+		want, err := constraint.Parse(line)
+		if err != nil {
+			t.Fatalf("constraint.Parse(%q) error: %v", line, err)
+		}
+		if !isPlusBuild(line) {
+			t.Errorf("isPlusBuild(%q) = false, want true", line)
+		}
+		got, err := parsePlusBuild(line)
+		if err != nil {
+			t.Errorf("parsePlusBuild(%q) error: %v", line, err)
+		} else if got.String() != want.String() {
+			t.Errorf("parsePlusBuild(%q) = %q, want %q", line, got, want)
+		}
+	}
+}
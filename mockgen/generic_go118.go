@@ -0,0 +1,66 @@
+// Copyright 2010 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//go:build go1.18
+// +build go1.18
+
+package main
+
+import (
+	"go/ast"
+
+	"github.com/golang/mock/mockgen/model"
+)
+
+// getTypeSpecTypeParams returns the type parameters of ts, or nil if it does
+// not declare a generic type.
+func getTypeSpecTypeParams(ts *ast.TypeSpec) []*ast.Field {
+	if ts.TypeParams == nil {
+		return nil
+	}
+	return ts.TypeParams.List
+}
+
+// parseGenericType parses typ if it is an instantiation of a generic type,
+// such as Set[T] or Pair[string, T]. It returns a nil Type otherwise.
+func (p *fileParser) parseGenericType(pkg string, typ ast.Expr) (model.Type, error) {
+	var x ast.Expr
+	var indices []ast.Expr
+	switch v := typ.(type) {
+	case *ast.IndexExpr:
+		x, indices = v.X, []ast.Expr{v.Index}
+	case *ast.IndexListExpr:
+		x, indices = v.X, v.Indices
+	default:
+		return nil, nil
+	}
+
+	t, err := p.parseType(pkg, x)
+	if err != nil {
+		return nil, err
+	}
+	nt, ok := t.(*model.NamedType)
+	if !ok {
+		return nil, p.errorf(x.Pos(), "can't instantiate non-generic type %v", t.String(nil, ""))
+	}
+	args := make([]model.Type, len(indices))
+	for i, index := range indices {
+		if args[i], err = p.parseType(pkg, index); err != nil {
+			return nil, err
+		}
+	}
+	nt.TypeParams = &model.TypeParametersType{TypeParameters: args}
+	return nt, nil
+}
@@ -0,0 +1,68 @@
+//go:build go1.18
+// +build go1.18
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/mockgen/model"
+)
+
+func TestSourceMode_Generic(t *testing.T) {
+	pkg, err := sourceMode("internal/tests/generic_comparable/input.go")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(pkg.Interfaces) != 1 {
+		t.Fatalf("Interfaces = %v, want only Set", pkg.Interfaces)
+	}
+	intf := pkg.Interfaces[0]
+	if len(intf.TypeParams) != 1 || intf.TypeParams[0].Name != "T" ||
+		intf.TypeParams[0].Type != model.PredeclaredType("comparable") {
+		t.Fatalf("TypeParams of Set = %v, want T comparable", intf.TypeParams)
+	}
+
+	var buf bytes.Buffer
+	intf.Print(&buf)
+	for _, want := range []string{"- T: comparable", `- "": T`, "- other: Set[T]"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Set is printed as\n%s\nwant it to contain %q", buf.String(), want)
+		}
+	}
+}
+
+func TestGenerateMockInterface_Generic(t *testing.T) {
+	intf := &model.Interface{
+		Name: "Cache",
+		TypeParams: []*model.Parameter{
+			{Name: "K", Type: model.PredeclaredType("comparable")},
+			{Name: "V", Type: model.PredeclaredType("any")},
+		},
+		Methods: []*model.Method{{
+			Name: "Get",
+			In:   []*model.Parameter{{Name: "key", Type: model.PredeclaredType("K")}},
+			Out:  []*model.Parameter{{Type: model.PredeclaredType("V")}},
+		}},
+	}
+	g := generator{interfaceTypes: map[string]string{"Cache": "cache.Cache"}}
+	if err := g.GenerateMockInterface(intf, ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := string(g.Output())
+	for _, want := range []string{
+		"type MockCache[K comparable, V any] struct {",
+		"recorder *MockCacheMockRecorder[K, V]",
+		"func _[K comparable, V any]() { var _ cache.Cache[K, V] = (*MockCache[K, V])(nil) }",
+		"func NewMockCache[K comparable, V any](ctrl gomock.ControllerInterface) *MockCache[K, V] {",
+		"func (m *MockCache[K, V]) Get(key K) V {",
+		"func (mr *MockCacheMockRecorder[K, V]) Get(key interface{}) *gomock.Call {",
+		"reflect.TypeOf((*MockCache[K, V])(nil).Get)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2010 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//go:build !go1.18
+// +build !go1.18
+
+package main
+
+import (
+	"go/ast"
+
+	"github.com/golang/mock/mockgen/model"
+)
+
+// getTypeSpecTypeParams returns nil, since generic types cannot be parsed
+// before Go 1.18.
+func getTypeSpecTypeParams(ts *ast.TypeSpec) []*ast.Field {
+	return nil
+}
+
+// parseGenericType returns a nil Type, since generic types cannot be parsed
+// before Go 1.18.
+func (p *fileParser) parseGenericType(pkg string, typ ast.Expr) (model.Type, error) {
+	return nil, nil
+}
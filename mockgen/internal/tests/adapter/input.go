@@ -0,0 +1,28 @@
+//go:generate mockgen -adapter Printer=Logger,Store=Cache -package adapter -destination mock.go -source input.go
+
+package adapter
+
+import "time"
+
+// Logger and Printer are compatible, as are Cache and Store, whose method
+// set is a subset of Store's.
+type Logger interface {
+	Logf(format string, args ...interface{})
+	Flush() error
+}
+
+type Printer interface {
+	Logf(format string, args ...interface{})
+	Flush() error
+}
+
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Put(key string, value []byte, ttl time.Duration)
+}
+
+type Store interface {
+	Get(key string) ([]byte, bool)
+	Put(key string, value []byte, ttl time.Duration)
+	Delete(key string) error
+}
@@ -0,0 +1,33 @@
+package adapter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestAdapter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	printer := NewMockPrinter(ctrl)
+	printer.EXPECT().Logf("%v items", 3)
+	printer.EXPECT().Flush().Return(nil)
+
+	var logger Logger = NewPrinterAsLogger(printer)
+	logger.Logf("%v items", 3)
+	if err := logger.Flush(); err != nil {
+		t.Errorf("Flush() error = %v", err)
+	}
+
+	store := NewMockStore(ctrl)
+	store.EXPECT().Put("k", []byte("v"), time.Minute)
+	store.EXPECT().Get("k").Return([]byte("v"), true)
+
+	var cache Cache = NewStoreAsCache(store)
+	cache.Put("k", []byte("v"), time.Minute)
+	if v, ok := cache.Get("k"); !ok || string(v) != "v" {
+		t.Errorf("Get() = %q, %v, want %q, true", v, ok, "v")
+	}
+}
@@ -0,0 +1,291 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+
+// Package adapter is a generated GoMock package.
+package adapter
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+	time "time"
+)
+
+// MockLogger is a mock of Logger interface
+type MockLogger struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockLoggerMockRecorder
+}
+
+// MockLoggerMockRecorder is the mock recorder for MockLogger
+type MockLoggerMockRecorder struct {
+	mock *MockLogger
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ Logger = (*MockLogger)(nil)
+
+// NewMockLogger creates a new mock instance
+func NewMockLogger(ctrl gomock.ControllerInterface) *MockLogger {
+	mock := &MockLogger{ctrl: ctrl}
+	mock.recorder = &MockLoggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockLogger) EXPECT() *MockLoggerMockRecorder {
+	return m.recorder
+}
+
+// Logf mocks base method
+func (m *MockLogger) Logf(format string, args ...interface{}) {
+	m.ctrl.TestHelper().Helper()
+	varargs := []interface{}{format}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Logf", varargs...)
+}
+
+// Logf indicates an expected call of Logf
+func (mr *MockLoggerMockRecorder) Logf(format interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	varargs := append([]interface{}{format}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logf", reflect.TypeOf((*MockLogger)(nil).Logf), varargs...)
+}
+
+// Flush mocks base method
+func (m *MockLogger) Flush() error {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Flush")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush
+func (mr *MockLoggerMockRecorder) Flush() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockLogger)(nil).Flush))
+}
+
+// MockPrinter is a mock of Printer interface
+type MockPrinter struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockPrinterMockRecorder
+}
+
+// MockPrinterMockRecorder is the mock recorder for MockPrinter
+type MockPrinterMockRecorder struct {
+	mock *MockPrinter
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ Printer = (*MockPrinter)(nil)
+
+// NewMockPrinter creates a new mock instance
+func NewMockPrinter(ctrl gomock.ControllerInterface) *MockPrinter {
+	mock := &MockPrinter{ctrl: ctrl}
+	mock.recorder = &MockPrinterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPrinter) EXPECT() *MockPrinterMockRecorder {
+	return m.recorder
+}
+
+// Logf mocks base method
+func (m *MockPrinter) Logf(format string, args ...interface{}) {
+	m.ctrl.TestHelper().Helper()
+	varargs := []interface{}{format}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Logf", varargs...)
+}
+
+// Logf indicates an expected call of Logf
+func (mr *MockPrinterMockRecorder) Logf(format interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	varargs := append([]interface{}{format}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logf", reflect.TypeOf((*MockPrinter)(nil).Logf), varargs...)
+}
+
+// Flush mocks base method
+func (m *MockPrinter) Flush() error {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Flush")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush
+func (mr *MockPrinterMockRecorder) Flush() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockPrinter)(nil).Flush))
+}
+
+// MockCache is a mock of Cache interface
+type MockCache struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockCacheMockRecorder
+}
+
+// MockCacheMockRecorder is the mock recorder for MockCache
+type MockCacheMockRecorder struct {
+	mock *MockCache
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ Cache = (*MockCache)(nil)
+
+// NewMockCache creates a new mock instance
+func NewMockCache(ctrl gomock.ControllerInterface) *MockCache {
+	mock := &MockCache{ctrl: ctrl}
+	mock.recorder = &MockCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockCache) EXPECT() *MockCacheMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method
+func (m *MockCache) Get(key string) ([]byte, bool) {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockCacheMockRecorder) Get(key interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCache)(nil).Get), key)
+}
+
+// Put mocks base method
+func (m *MockCache) Put(key string, value []byte, ttl time.Duration) {
+	m.ctrl.TestHelper().Helper()
+	m.ctrl.Call(m, "Put", key, value, ttl)
+}
+
+// Put indicates an expected call of Put
+func (mr *MockCacheMockRecorder) Put(key, value, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockCache)(nil).Put), key, value, ttl)
+}
+
+// MockStore is a mock of Store interface
+type MockStore struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockStoreMockRecorder
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ Store = (*MockStore)(nil)
+
+// NewMockStore creates a new mock instance
+func NewMockStore(ctrl gomock.ControllerInterface) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method
+func (m *MockStore) Get(key string) ([]byte, bool) {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockStoreMockRecorder) Get(key interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStore)(nil).Get), key)
+}
+
+// Put mocks base method
+func (m *MockStore) Put(key string, value []byte, ttl time.Duration) {
+	m.ctrl.TestHelper().Helper()
+	m.ctrl.Call(m, "Put", key, value, ttl)
+}
+
+// Put indicates an expected call of Put
+func (mr *MockStoreMockRecorder) Put(key, value, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockStore)(nil).Put), key, value, ttl)
+}
+
+// Delete mocks base method
+func (m *MockStore) Delete(key string) error {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Delete", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockStoreMockRecorder) Delete(key interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStore)(nil).Delete), key)
+}
+
+// PrinterAsLogger adapts a Printer to the Logger interface
+type PrinterAsLogger struct {
+	adapted Printer
+}
+
+// Verify that the adapter satisfies the interface at compile time.
+var _ Logger = (*PrinterAsLogger)(nil)
+
+// NewPrinterAsLogger returns an adapter delegating to adapted
+func NewPrinterAsLogger(adapted Printer) *PrinterAsLogger {
+	return &PrinterAsLogger{adapted: adapted}
+}
+
+// Logf delegates to the adapted Printer
+func (a *PrinterAsLogger) Logf(format string, args ...interface{}) {
+	a.adapted.Logf(format, args...)
+}
+
+// Flush delegates to the adapted Printer
+func (a *PrinterAsLogger) Flush() error {
+	return a.adapted.Flush()
+}
+
+// StoreAsCache adapts a Store to the Cache interface
+type StoreAsCache struct {
+	adapted Store
+}
+
+// Verify that the adapter satisfies the interface at compile time.
+var _ Cache = (*StoreAsCache)(nil)
+
+// NewStoreAsCache returns an adapter delegating to adapted
+func NewStoreAsCache(adapted Store) *StoreAsCache {
+	return &StoreAsCache{adapted: adapted}
+}
+
+// Get delegates to the adapted Store
+func (a *StoreAsCache) Get(key string) ([]byte, bool) {
+	return a.adapted.Get(key)
+}
+
+// Put delegates to the adapted Store
+func (a *StoreAsCache) Put(key string, value []byte, ttl time.Duration) {
+	a.adapted.Put(key, value, ttl)
+}
@@ -0,0 +1,12 @@
+//go:generate mockgen -package also_implement -destination mock.go -source input.go -also_implement Store=Closer
+package also_implement
+
+// Store is the interface being mocked.
+type Store interface {
+	Get(key string) (string, error)
+}
+
+// Closer is a marker interface the mock of Store must also satisfy.
+type Closer interface {
+	Close() error
+}
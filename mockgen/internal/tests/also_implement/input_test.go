@@ -0,0 +1,25 @@
+package also_implement
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+var (
+	_ Store  = (*MockStore)(nil)
+	_ Closer = (*MockStore)(nil)
+)
+
+func TestMockStoreIsCloser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockStore(ctrl)
+	m.EXPECT().Close().Return(nil)
+
+	var c Closer = m
+	if err := c.Close(); err != nil {
+		t.Errorf("Close() = %v, want nil", err)
+	}
+}
@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+
+// Package also_implement is a generated GoMock package.
+package also_implement
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockStore is a mock of Store interface
+type MockStore struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockStoreMockRecorder
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ Store = (*MockStore)(nil)
+
+// NewMockStore creates a new mock instance
+func NewMockStore(ctrl gomock.ControllerInterface) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method
+func (m *MockStore) Get(key string) (string, error) {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockStoreMockRecorder) Get(key interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStore)(nil).Get), key)
+}
+
+// Close mocks base method
+func (m *MockStore) Close() error {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close
+func (mr *MockStoreMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockStore)(nil).Close))
+}
+
+// MockCloser is a mock of Closer interface
+type MockCloser struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockCloserMockRecorder
+}
+
+// MockCloserMockRecorder is the mock recorder for MockCloser
+type MockCloserMockRecorder struct {
+	mock *MockCloser
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ Closer = (*MockCloser)(nil)
+
+// NewMockCloser creates a new mock instance
+func NewMockCloser(ctrl gomock.ControllerInterface) *MockCloser {
+	mock := &MockCloser{ctrl: ctrl}
+	mock.recorder = &MockCloserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockCloser) EXPECT() *MockCloserMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method
+func (m *MockCloser) Close() error {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close
+func (mr *MockCloserMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockCloser)(nil).Close))
+}
@@ -0,0 +1,7 @@
+// Package any_type tests that the empty interface is spelled consistently in
+// generated mocks, however it is spelled in the input.
+package any_type
+
+// any stands in for the predeclared any of Go 1.18, which this module
+// predates.
+type any = interface{}
@@ -0,0 +1,11 @@
+//go:generate mockgen -destination mock_any_type/mock.go -source input.go
+//go:generate mockgen -use_any -package any_type -destination mock_any.go -source input.go
+
+package any_type
+
+type Cache interface {
+	Get(key string) (any, bool)
+	Set(key string, value interface{})
+	Update(key string, f func(old any) interface{}) map[string]any
+	SetAll(values ...any)
+}
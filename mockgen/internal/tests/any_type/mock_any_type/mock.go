@@ -0,0 +1,90 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+
+// Package mock_any_type is a generated GoMock package.
+package mock_any_type
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockCache is a mock of Cache interface
+type MockCache struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockCacheMockRecorder
+}
+
+// MockCacheMockRecorder is the mock recorder for MockCache
+type MockCacheMockRecorder struct {
+	mock *MockCache
+}
+
+// NewMockCache creates a new mock instance
+func NewMockCache(ctrl gomock.ControllerInterface) *MockCache {
+	mock := &MockCache{ctrl: ctrl}
+	mock.recorder = &MockCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockCache) EXPECT() *MockCacheMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method
+func (m *MockCache) Get(key string) (interface{}, bool) {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockCacheMockRecorder) Get(key interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCache)(nil).Get), key)
+}
+
+// Set mocks base method
+func (m *MockCache) Set(key string, value interface{}) {
+	m.ctrl.TestHelper().Helper()
+	m.ctrl.Call(m, "Set", key, value)
+}
+
+// Set indicates an expected call of Set
+func (mr *MockCacheMockRecorder) Set(key, value interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCache)(nil).Set), key, value)
+}
+
+// Update mocks base method
+func (m *MockCache) Update(key string, f func(interface{}) interface{}) map[string]interface{} {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Update", key, f)
+	ret0, _ := ret[0].(map[string]interface{})
+	return ret0
+}
+
+// Update indicates an expected call of Update
+func (mr *MockCacheMockRecorder) Update(key, f interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCache)(nil).Update), key, f)
+}
+
+// SetAll mocks base method
+func (m *MockCache) SetAll(values ...interface{}) {
+	m.ctrl.TestHelper().Helper()
+	varargs := []interface{}{}
+	for _, a := range values {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "SetAll", varargs...)
+}
+
+// SetAll indicates an expected call of SetAll
+func (mr *MockCacheMockRecorder) SetAll(values ...interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAll", reflect.TypeOf((*MockCache)(nil).SetAll), values...)
+}
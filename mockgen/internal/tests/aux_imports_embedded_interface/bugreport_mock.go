@@ -12,7 +12,7 @@ import (
 
 // MockSource is a mock of Source interface
 type MockSource struct {
-	ctrl     *gomock.Controller
+	ctrl     gomock.ControllerInterface
 	recorder *MockSourceMockRecorder
 }
 
@@ -21,8 +21,11 @@ type MockSourceMockRecorder struct {
 	mock *MockSource
 }
 
+// Verify that the mock satisfies the interface at compile time.
+var _ Source = (*MockSource)(nil)
+
 // NewMockSource creates a new mock instance
-func NewMockSource(ctrl *gomock.Controller) *MockSource {
+func NewMockSource(ctrl gomock.ControllerInterface) *MockSource {
 	mock := &MockSource{ctrl: ctrl}
 	mock.recorder = &MockSourceMockRecorder{mock}
 	return mock
@@ -35,7 +38,7 @@ func (m *MockSource) EXPECT() *MockSourceMockRecorder {
 
 // Method mocks base method
 func (m *MockSource) Method() faux.Return {
-	m.ctrl.T.Helper()
+	m.ctrl.TestHelper().Helper()
 	ret := m.ctrl.Call(m, "Method")
 	ret0, _ := ret[0].(faux.Return)
 	return ret0
@@ -43,6 +46,6 @@ func (m *MockSource) Method() faux.Return {
 
 // Method indicates an expected call of Method
 func (mr *MockSourceMockRecorder) Method() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
+	mr.mock.ctrl.TestHelper().Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Method", reflect.TypeOf((*MockSource)(nil).Method))
 }
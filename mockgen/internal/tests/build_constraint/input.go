@@ -0,0 +1,12 @@
+//go:build !plan9
+// +build !plan9
+
+//go:generate mockgen -destination mock.go -package build_constraint -source input.go
+
+package build_constraint
+
+// Watcher only exists where the file builds, so its mock must carry the same
+// build constraint.
+type Watcher interface {
+	Watch(path string) error
+}
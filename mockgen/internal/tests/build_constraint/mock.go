@@ -0,0 +1,53 @@
+//go:build !plan9
+// +build !plan9
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+
+// Package build_constraint is a generated GoMock package.
+package build_constraint
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockWatcher is a mock of Watcher interface
+type MockWatcher struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockWatcherMockRecorder
+}
+
+// MockWatcherMockRecorder is the mock recorder for MockWatcher
+type MockWatcherMockRecorder struct {
+	mock *MockWatcher
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ Watcher = (*MockWatcher)(nil)
+
+// NewMockWatcher creates a new mock instance
+func NewMockWatcher(ctrl gomock.ControllerInterface) *MockWatcher {
+	mock := &MockWatcher{ctrl: ctrl}
+	mock.recorder = &MockWatcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockWatcher) EXPECT() *MockWatcherMockRecorder {
+	return m.recorder
+}
+
+// Watch mocks base method
+func (m *MockWatcher) Watch(path string) error {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Watch", path)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch
+func (mr *MockWatcherMockRecorder) Watch(path interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockWatcher)(nil).Watch), path)
+}
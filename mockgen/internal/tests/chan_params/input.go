@@ -0,0 +1,17 @@
+//go:generate mockgen -destination source_output/mock.go -source input.go
+//go:generate mockgen -destination reflect_output/mock.go github.com/golang/mock/mockgen/internal/tests/chan_params Worker
+
+package chan_params
+
+// Job is a unit of work.
+type Job struct {
+	ID int
+}
+
+// Worker has methods taking channels of each direction.
+type Worker interface {
+	Process(in <-chan Job) error
+	Emit(out chan<- Job)
+	Pipe(in <-chan Job, out chan<- Job, done chan struct{})
+	Fan(ins chan (<-chan Job), outs chan<- chan<- Job)
+}
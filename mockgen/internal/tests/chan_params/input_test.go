@@ -0,0 +1,108 @@
+package chan_params_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/mock/mockgen/internal/tests/chan_params"
+	reflect_output "github.com/golang/mock/mockgen/internal/tests/chan_params/reflect_output"
+	source_output "github.com/golang/mock/mockgen/internal/tests/chan_params/source_output"
+)
+
+// recorder is implemented by the recorders of the mocks generated in source
+// and reflect mode.
+type recorder interface {
+	Process(in interface{}) *gomock.Call
+	Emit(out interface{}) *gomock.Call
+	Pipe(in, out, done interface{}) *gomock.Call
+	Fan(ins, outs interface{}) *gomock.Call
+}
+
+type mock struct {
+	chan_params.Worker
+	expect recorder
+}
+
+// newMocks returns the mocks generated in source and reflect mode.
+func newMocks(ctrl *gomock.Controller) map[string]mock {
+	s := source_output.NewMockWorker(ctrl)
+	r := reflect_output.NewMockWorker(ctrl)
+	return map[string]mock{
+		"source":  {s, s.EXPECT()},
+		"reflect": {r, r.EXPECT()},
+	}
+}
+
+func TestSameChannel(t *testing.T) {
+	for mode, m := range newMocks(gomock.NewController(t)) {
+		t.Run(mode, func(t *testing.T) {
+			in := make(chan chan_params.Job)
+			out := make(chan chan_params.Job, 1)
+			m.expect.Process(gomock.Same(in)).Return(nil)
+			m.expect.Pipe(gomock.Same(in), gomock.Same(out), gomock.Any())
+			m.expect.Emit(gomock.Same(out)).Do(func(out chan<- chan_params.Job) {
+				out <- chan_params.Job{ID: 1}
+			})
+
+			if err := m.Process(in); err != nil {
+				t.Errorf("Process() error = %v", err)
+			}
+			m.Pipe(in, out, make(chan struct{}))
+			m.Emit(out)
+			if job := <-out; job.ID != 1 {
+				t.Errorf("Emit sent %v, want job 1", job)
+			}
+		})
+	}
+}
+
+func TestSameChannel_Nested(t *testing.T) {
+	for mode, m := range newMocks(gomock.NewController(t)) {
+		t.Run(mode, func(t *testing.T) {
+			ins := make(chan (<-chan chan_params.Job))
+			outs := make(chan chan<- chan_params.Job)
+			m.expect.Fan(gomock.Same(ins), gomock.Same(outs))
+
+			m.Fan(ins, outs)
+		})
+	}
+}
+
+// fatalReporter records the message of a fatal failure and stops the
+// calling function by panicking.
+type fatalReporter struct {
+	msg string
+}
+
+type fatalPanic struct{}
+
+func (r *fatalReporter) Errorf(format string, args ...interface{}) {}
+
+func (r *fatalReporter) Fatalf(format string, args ...interface{}) {
+	r.msg = fmt.Sprintf(format, args...)
+	panic(fatalPanic{})
+}
+
+func TestSameChannel_OtherChannel(t *testing.T) {
+	reporter := new(fatalReporter)
+	for mode, m := range newMocks(gomock.NewController(reporter)) {
+		t.Run(mode, func(t *testing.T) {
+			in := make(chan chan_params.Job)
+			m.expect.Process(gomock.Same(in)).Return(nil).AnyTimes()
+
+			func() {
+				defer func() {
+					if r := recover(); r != nil && r != (fatalPanic{}) {
+						panic(r)
+					}
+				}()
+				m.Process(make(chan chan_params.Job))
+			}()
+			if want := "is the same chan chan_params.Job as"; !strings.Contains(reporter.msg, want) {
+				t.Errorf("failure = %q, want to contain %q", reporter.msg, want)
+			}
+		})
+	}
+}
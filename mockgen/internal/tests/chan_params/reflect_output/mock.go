@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/golang/mock/mockgen/internal/tests/chan_params (interfaces: Worker)
+
+// Package mock_chan_params is a generated GoMock package.
+package mock_chan_params
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	chan_params "github.com/golang/mock/mockgen/internal/tests/chan_params"
+	reflect "reflect"
+)
+
+// MockWorker is a mock of Worker interface
+type MockWorker struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockWorkerMockRecorder
+}
+
+// MockWorkerMockRecorder is the mock recorder for MockWorker
+type MockWorkerMockRecorder struct {
+	mock *MockWorker
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ chan_params.Worker = (*MockWorker)(nil)
+
+// NewMockWorker creates a new mock instance
+func NewMockWorker(ctrl gomock.ControllerInterface) *MockWorker {
+	mock := &MockWorker{ctrl: ctrl}
+	mock.recorder = &MockWorkerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockWorker) EXPECT() *MockWorkerMockRecorder {
+	return m.recorder
+}
+
+// Emit mocks base method
+func (m *MockWorker) Emit(arg0 chan<- chan_params.Job) {
+	m.ctrl.TestHelper().Helper()
+	m.ctrl.Call(m, "Emit", arg0)
+}
+
+// Emit indicates an expected call of Emit
+func (mr *MockWorkerMockRecorder) Emit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Emit", reflect.TypeOf((*MockWorker)(nil).Emit), arg0)
+}
+
+// Fan mocks base method
+func (m *MockWorker) Fan(arg0 chan (<-chan chan_params.Job), arg1 chan<- chan<- chan_params.Job) {
+	m.ctrl.TestHelper().Helper()
+	m.ctrl.Call(m, "Fan", arg0, arg1)
+}
+
+// Fan indicates an expected call of Fan
+func (mr *MockWorkerMockRecorder) Fan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fan", reflect.TypeOf((*MockWorker)(nil).Fan), arg0, arg1)
+}
+
+// Pipe mocks base method
+func (m *MockWorker) Pipe(arg0 <-chan chan_params.Job, arg1 chan<- chan_params.Job, arg2 chan struct{}) {
+	m.ctrl.TestHelper().Helper()
+	m.ctrl.Call(m, "Pipe", arg0, arg1, arg2)
+}
+
+// Pipe indicates an expected call of Pipe
+func (mr *MockWorkerMockRecorder) Pipe(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pipe", reflect.TypeOf((*MockWorker)(nil).Pipe), arg0, arg1, arg2)
+}
+
+// Process mocks base method
+func (m *MockWorker) Process(arg0 <-chan chan_params.Job) error {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Process", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Process indicates an expected call of Process
+func (mr *MockWorkerMockRecorder) Process(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Process", reflect.TypeOf((*MockWorker)(nil).Process), arg0)
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -destination source_output/mock.go -source input.go
+//go:generate mockgen -destination reflect_output/mock.go github.com/golang/mock/mockgen/internal/tests/chan_return Subscriber
+
+package chan_return
+
+// Event is sent to subscribers.
+type Event struct {
+	Topic   string
+	Payload []byte
+}
+
+// Subscriber has methods taking and returning channels of structs.
+type Subscriber interface {
+	Subscribe(topic string) (<-chan Event, error)
+	Forward(dst chan<- Event) chan Event
+}
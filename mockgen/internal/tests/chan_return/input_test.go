@@ -0,0 +1,95 @@
+package chan_return_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/mock/mockgen/internal/tests/chan_return"
+	reflect_output "github.com/golang/mock/mockgen/internal/tests/chan_return/reflect_output"
+	source_output "github.com/golang/mock/mockgen/internal/tests/chan_return/source_output"
+)
+
+// newMocks returns the mocks generated in source and reflect mode.
+func newMocks(ctrl *gomock.Controller) map[string]chan_return.Subscriber {
+	return map[string]chan_return.Subscriber{
+		"source":  source_output.NewMockSubscriber(ctrl),
+		"reflect": reflect_output.NewMockSubscriber(ctrl),
+	}
+}
+
+// expectSubscribe returns the recorder's Subscribe expectation of either mock.
+func expectSubscribe(m chan_return.Subscriber, topic string) *gomock.Call {
+	switch m := m.(type) {
+	case *source_output.MockSubscriber:
+		return m.EXPECT().Subscribe(topic)
+	case *reflect_output.MockSubscriber:
+		return m.EXPECT().Subscribe(topic)
+	}
+	panic(fmt.Sprintf("unknown mock %T", m))
+}
+
+func TestSubscribe(t *testing.T) {
+	for mode, m := range newMocks(gomock.NewController(t)) {
+		t.Run(mode, func(t *testing.T) {
+			// A bidirectional channel is assignable to the receive-only
+			// return type.
+			ch := make(chan chan_return.Event, 2)
+			expectSubscribe(m, "news").Return(ch, nil)
+
+			events, err := m.Subscribe("news")
+			if err != nil {
+				t.Fatalf("Subscribe() error = %v", err)
+			}
+			want := []chan_return.Event{{Topic: "news", Payload: []byte("a")}, {Topic: "news", Payload: []byte("b")}}
+			for _, e := range want {
+				ch <- e
+			}
+			close(ch)
+
+			var got []chan_return.Event
+			for e := range events {
+				got = append(got, e)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("received %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// fatalReporter records the message of a fatal failure and stops the
+// calling function by panicking.
+type fatalReporter struct {
+	msg string
+}
+
+type fatalPanic struct{}
+
+func (r *fatalReporter) Errorf(format string, args ...interface{}) {}
+
+func (r *fatalReporter) Fatalf(format string, args ...interface{}) {
+	r.msg = fmt.Sprintf(format, args...)
+	panic(fatalPanic{})
+}
+
+func TestSubscribe_WrongElementType(t *testing.T) {
+	reporter := new(fatalReporter)
+	for mode, m := range newMocks(gomock.NewController(reporter)) {
+		t.Run(mode, func(t *testing.T) {
+			func() {
+				defer func() {
+					if r := recover(); r != nil && r != (fatalPanic{}) {
+						panic(r)
+					}
+				}()
+				expectSubscribe(m, "news").Return(make(chan string), nil)
+			}()
+			if want := "chan string is not assignable to <-chan chan_return.Event"; !strings.Contains(reporter.msg, want) {
+				t.Errorf("failure = %q, want to contain %q", reporter.msg, want)
+			}
+		})
+	}
+}
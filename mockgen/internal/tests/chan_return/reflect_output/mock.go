@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/golang/mock/mockgen/internal/tests/chan_return (interfaces: Subscriber)
+
+// Package mock_chan_return is a generated GoMock package.
+package mock_chan_return
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	chan_return "github.com/golang/mock/mockgen/internal/tests/chan_return"
+	reflect "reflect"
+)
+
+// MockSubscriber is a mock of Subscriber interface
+type MockSubscriber struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockSubscriberMockRecorder
+}
+
+// MockSubscriberMockRecorder is the mock recorder for MockSubscriber
+type MockSubscriberMockRecorder struct {
+	mock *MockSubscriber
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ chan_return.Subscriber = (*MockSubscriber)(nil)
+
+// NewMockSubscriber creates a new mock instance
+func NewMockSubscriber(ctrl gomock.ControllerInterface) *MockSubscriber {
+	mock := &MockSubscriber{ctrl: ctrl}
+	mock.recorder = &MockSubscriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSubscriber) EXPECT() *MockSubscriberMockRecorder {
+	return m.recorder
+}
+
+// Forward mocks base method
+func (m *MockSubscriber) Forward(arg0 chan<- chan_return.Event) chan chan_return.Event {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Forward", arg0)
+	ret0, _ := ret[0].(chan chan_return.Event)
+	return ret0
+}
+
+// Forward indicates an expected call of Forward
+func (mr *MockSubscriberMockRecorder) Forward(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Forward", reflect.TypeOf((*MockSubscriber)(nil).Forward), arg0)
+}
+
+// Subscribe mocks base method
+func (m *MockSubscriber) Subscribe(arg0 string) (<-chan chan_return.Event, error) {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Subscribe", arg0)
+	ret0, _ := ret[0].(<-chan chan_return.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe
+func (mr *MockSubscriberMockRecorder) Subscribe(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockSubscriber)(nil).Subscribe), arg0)
+}
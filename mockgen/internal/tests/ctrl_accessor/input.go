@@ -0,0 +1,8 @@
+//go:generate mockgen -ctrl_accessor -package ctrl_accessor -destination mock.go -source input.go
+
+package ctrl_accessor
+
+// Store is mocked with a Ctrl accessor.
+type Store interface {
+	Get(key string) (string, error)
+}
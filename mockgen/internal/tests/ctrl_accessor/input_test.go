@@ -0,0 +1,32 @@
+package ctrl_accessor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+// expectMissing sets up store to report any key as missing, reaching the
+// controller through the mock to name it in failure messages.
+func expectMissing(store *MockStore) {
+	store.Ctrl().NameMock(store, "emptyStore")
+	store.EXPECT().Get(gomock.Any()).Return("", errNotFound).AnyTimes()
+}
+
+var errNotFound = errors.New("not found")
+
+func TestCtrlAccessor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := NewMockStore(ctrl)
+	if store.Ctrl() != ctrl {
+		t.Fatalf("Ctrl() = %p, want %p", store.Ctrl(), ctrl)
+	}
+
+	expectMissing(store)
+	if _, err := store.Get("key"); err != errNotFound {
+		t.Errorf("Get() error = %v, want %v", err, errNotFound)
+	}
+}
@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+
+// Package ctrl_accessor is a generated GoMock package.
+package ctrl_accessor
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockStore is a mock of Store interface
+type MockStore struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockStoreMockRecorder
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ Store = (*MockStore)(nil)
+
+// NewMockStore creates a new mock instance
+func NewMockStore(ctrl gomock.ControllerInterface) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// Ctrl returns the controller the mock was created with, or nil if it is
+// not a *gomock.Controller
+func (m *MockStore) Ctrl() *gomock.Controller {
+	ctrl, _ := m.ctrl.(*gomock.Controller)
+	return ctrl
+}
+
+// Get mocks base method
+func (m *MockStore) Get(key string) (string, error) {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockStoreMockRecorder) Get(key interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStore)(nil).Get), key)
+}
@@ -0,0 +1,8 @@
+//go:generate mockgen -package custom_controller -destination mock.go -source input.go
+
+package custom_controller
+
+// Greeter is mocked with a custom controller.
+type Greeter interface {
+	Greet(name string) string
+}
@@ -0,0 +1,35 @@
+package custom_controller
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+// loggingController wraps a *gomock.Controller and logs every call made to
+// the mocks it controls.
+type loggingController struct {
+	*gomock.Controller
+	log []string
+}
+
+func (c *loggingController) Call(receiver interface{}, method string, args ...interface{}) []interface{} {
+	c.log = append(c.log, method)
+	return c.Controller.Call(receiver, method, args...)
+}
+
+func TestCustomController(t *testing.T) {
+	ctrl := &loggingController{Controller: gomock.NewController(t)}
+	defer ctrl.Finish()
+
+	m := NewMockGreeter(ctrl)
+	m.EXPECT().Greet("gopher").Return("hello, gopher")
+
+	if got := m.Greet("gopher"); got != "hello, gopher" {
+		t.Errorf("Greet() = %q, want %q", got, "hello, gopher")
+	}
+	if want := []string{"Greet"}; !reflect.DeepEqual(ctrl.log, want) {
+		t.Errorf("log = %v, want %v", ctrl.log, want)
+	}
+}
@@ -12,7 +12,7 @@ import (
 
 // MockInputMaker is a mock of InputMaker interface
 type MockInputMaker struct {
-	ctrl     *gomock.Controller
+	ctrl     gomock.ControllerInterface
 	recorder *MockInputMakerMockRecorder
 }
 
@@ -21,8 +21,11 @@ type MockInputMakerMockRecorder struct {
 	mock *MockInputMaker
 }
 
+// Verify that the mock satisfies the interface at compile time.
+var _ InputMaker = (*MockInputMaker)(nil)
+
 // NewMockInputMaker creates a new mock instance
-func NewMockInputMaker(ctrl *gomock.Controller) *MockInputMaker {
+func NewMockInputMaker(ctrl gomock.ControllerInterface) *MockInputMaker {
 	mock := &MockInputMaker{ctrl: ctrl}
 	mock.recorder = &MockInputMakerMockRecorder{mock}
 	return mock
@@ -35,7 +38,7 @@ func (m *MockInputMaker) EXPECT() *MockInputMakerMockRecorder {
 
 // MakeInput mocks base method
 func (m *MockInputMaker) MakeInput() client.GreetInput {
-	m.ctrl.T.Helper()
+	m.ctrl.TestHelper().Helper()
 	ret := m.ctrl.Call(m, "MakeInput")
 	ret0, _ := ret[0].(client.GreetInput)
 	return ret0
@@ -43,6 +46,6 @@ func (m *MockInputMaker) MakeInput() client.GreetInput {
 
 // MakeInput indicates an expected call of MakeInput
 func (mr *MockInputMakerMockRecorder) MakeInput() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
+	mr.mock.ctrl.TestHelper().Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeInput", reflect.TypeOf((*MockInputMaker)(nil).MakeInput))
 }
@@ -0,0 +1,26 @@
+//go:generate mockgen -package deep_embedding -destination mock.go -source input.go
+package deep_embedding
+
+import "github.com/golang/mock/mockgen/internal/tests/deep_embedding/other"
+
+// Base, Middle and Top embed each other three levels deep.
+type Base interface {
+	Close() error
+}
+
+type Middle interface {
+	Base
+	Name() string
+}
+
+type Top interface {
+	Middle
+	Run()
+}
+
+// Imported embeds an interface of another package at the top of a chain of
+// three levels of embedding within that package.
+type Imported interface {
+	other.Level2
+	Three() int
+}
@@ -0,0 +1,40 @@
+package deep_embedding
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+var (
+	_ Top      = (*MockTop)(nil)
+	_ Imported = (*MockImported)(nil)
+)
+
+func TestDeepEmbedding(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	top := NewMockTop(ctrl)
+	top.EXPECT().Close().Return(nil)
+	top.EXPECT().Name().Return("top")
+	top.EXPECT().Run()
+
+	if err := top.Close(); err != nil {
+		t.Errorf("Close() = %v, want nil", err)
+	}
+	if got := top.Name(); got != "top" {
+		t.Errorf("Name() = %q, want %q", got, "top")
+	}
+	top.Run()
+
+	imported := NewMockImported(ctrl)
+	imported.EXPECT().Zero().Return(0)
+	imported.EXPECT().One().Return(1)
+	imported.EXPECT().Two().Return(2)
+	imported.EXPECT().Three().Return(3)
+
+	if got := imported.Zero() + imported.One() + imported.Two() + imported.Three(); got != 6 {
+		t.Errorf("sum of levels = %d, want 6", got)
+	}
+}
@@ -0,0 +1,252 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+
+// Package deep_embedding is a generated GoMock package.
+package deep_embedding
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockBase is a mock of Base interface
+type MockBase struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockBaseMockRecorder
+}
+
+// MockBaseMockRecorder is the mock recorder for MockBase
+type MockBaseMockRecorder struct {
+	mock *MockBase
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ Base = (*MockBase)(nil)
+
+// NewMockBase creates a new mock instance
+func NewMockBase(ctrl gomock.ControllerInterface) *MockBase {
+	mock := &MockBase{ctrl: ctrl}
+	mock.recorder = &MockBaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockBase) EXPECT() *MockBaseMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method
+func (m *MockBase) Close() error {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close
+func (mr *MockBaseMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockBase)(nil).Close))
+}
+
+// MockMiddle is a mock of Middle interface
+type MockMiddle struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockMiddleMockRecorder
+}
+
+// MockMiddleMockRecorder is the mock recorder for MockMiddle
+type MockMiddleMockRecorder struct {
+	mock *MockMiddle
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ Middle = (*MockMiddle)(nil)
+
+// NewMockMiddle creates a new mock instance
+func NewMockMiddle(ctrl gomock.ControllerInterface) *MockMiddle {
+	mock := &MockMiddle{ctrl: ctrl}
+	mock.recorder = &MockMiddleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockMiddle) EXPECT() *MockMiddleMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method
+func (m *MockMiddle) Close() error {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close
+func (mr *MockMiddleMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockMiddle)(nil).Close))
+}
+
+// Name mocks base method
+func (m *MockMiddle) Name() string {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name
+func (mr *MockMiddleMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockMiddle)(nil).Name))
+}
+
+// MockTop is a mock of Top interface
+type MockTop struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockTopMockRecorder
+}
+
+// MockTopMockRecorder is the mock recorder for MockTop
+type MockTopMockRecorder struct {
+	mock *MockTop
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ Top = (*MockTop)(nil)
+
+// NewMockTop creates a new mock instance
+func NewMockTop(ctrl gomock.ControllerInterface) *MockTop {
+	mock := &MockTop{ctrl: ctrl}
+	mock.recorder = &MockTopMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockTop) EXPECT() *MockTopMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method
+func (m *MockTop) Close() error {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close
+func (mr *MockTopMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockTop)(nil).Close))
+}
+
+// Name mocks base method
+func (m *MockTop) Name() string {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name
+func (mr *MockTopMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockTop)(nil).Name))
+}
+
+// Run mocks base method
+func (m *MockTop) Run() {
+	m.ctrl.TestHelper().Helper()
+	m.ctrl.Call(m, "Run")
+}
+
+// Run indicates an expected call of Run
+func (mr *MockTopMockRecorder) Run() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockTop)(nil).Run))
+}
+
+// MockImported is a mock of Imported interface
+type MockImported struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockImportedMockRecorder
+}
+
+// MockImportedMockRecorder is the mock recorder for MockImported
+type MockImportedMockRecorder struct {
+	mock *MockImported
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ Imported = (*MockImported)(nil)
+
+// NewMockImported creates a new mock instance
+func NewMockImported(ctrl gomock.ControllerInterface) *MockImported {
+	mock := &MockImported{ctrl: ctrl}
+	mock.recorder = &MockImportedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockImported) EXPECT() *MockImportedMockRecorder {
+	return m.recorder
+}
+
+// Zero mocks base method
+func (m *MockImported) Zero() int {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Zero")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Zero indicates an expected call of Zero
+func (mr *MockImportedMockRecorder) Zero() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Zero", reflect.TypeOf((*MockImported)(nil).Zero))
+}
+
+// One mocks base method
+func (m *MockImported) One() int {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "One")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// One indicates an expected call of One
+func (mr *MockImportedMockRecorder) One() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "One", reflect.TypeOf((*MockImported)(nil).One))
+}
+
+// Two mocks base method
+func (m *MockImported) Two() int {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Two")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Two indicates an expected call of Two
+func (mr *MockImportedMockRecorder) Two() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Two", reflect.TypeOf((*MockImported)(nil).Two))
+}
+
+// Three mocks base method
+func (m *MockImported) Three() int {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Three")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Three indicates an expected call of Three
+func (mr *MockImportedMockRecorder) Three() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Three", reflect.TypeOf((*MockImported)(nil).Three))
+}
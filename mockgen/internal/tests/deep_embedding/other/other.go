@@ -0,0 +1,16 @@
+package other
+
+// Level0, Level1 and Level2 embed each other three levels deep.
+type Level0 interface {
+	Zero() int
+}
+
+type Level1 interface {
+	Level0
+	One() int
+}
+
+type Level2 interface {
+	Level1
+	Two() int
+}
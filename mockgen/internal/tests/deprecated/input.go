@@ -0,0 +1,18 @@
+//go:generate mockgen -package deprecated -destination mock.go -source input.go
+
+package deprecated
+
+// Store has deprecated methods, whose mocks must be deprecated too.
+type Store interface {
+	// Get returns the value stored under key.
+	Get(key string) (string, error)
+
+	// Fetch returns the value stored under key.
+	//
+	// Deprecated: Fetch does not report errors; use Get
+	// instead.
+	Fetch(key string) string
+
+	// Deprecated: use Get.
+	Lookup(key string) string
+}
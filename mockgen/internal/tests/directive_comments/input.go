@@ -0,0 +1,13 @@
+//go:generate mockgen -package directive_comments -destination mock.go -source input.go
+
+package directive_comments
+
+// Store has directives in its doc comments, which must not end up in the mock.
+//
+//nolint:interfacebloat
+//lint:ignore U1000 used by generated code
+type Store interface {
+	// Get returns the value of key.
+	//nolint:errcheck
+	Get(key string) (string, error) //nolint:lll
+}
@@ -1,4 +1,5 @@
 //go:generate mockgen -package dot_imports -destination mock.go -source input.go
+//go:generate mockgen -destination mock_dot_imports/mock.go -source input.go
 package dot_imports
 
 import (
@@ -12,3 +13,8 @@ type WithDotImports interface {
 	Method2() *bytes.Buffer
 	Method3() Context
 }
+
+// WithDotImportedEmbed embeds an interface of a dot import.
+type WithDotImportedEmbed interface {
+	Context
+}
@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+
+// Package mock_dot_imports is a generated GoMock package.
+package mock_dot_imports
+
+import (
+	bytes "bytes"
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	http "net/http"
+	reflect "reflect"
+	time "time"
+)
+
+// MockWithDotImports is a mock of WithDotImports interface
+type MockWithDotImports struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockWithDotImportsMockRecorder
+}
+
+// MockWithDotImportsMockRecorder is the mock recorder for MockWithDotImports
+type MockWithDotImportsMockRecorder struct {
+	mock *MockWithDotImports
+}
+
+// NewMockWithDotImports creates a new mock instance
+func NewMockWithDotImports(ctrl gomock.ControllerInterface) *MockWithDotImports {
+	mock := &MockWithDotImports{ctrl: ctrl}
+	mock.recorder = &MockWithDotImportsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockWithDotImports) EXPECT() *MockWithDotImportsMockRecorder {
+	return m.recorder
+}
+
+// Method1 mocks base method
+func (m *MockWithDotImports) Method1() http.Request {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Method1")
+	ret0, _ := ret[0].(http.Request)
+	return ret0
+}
+
+// Method1 indicates an expected call of Method1
+func (mr *MockWithDotImportsMockRecorder) Method1() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Method1", reflect.TypeOf((*MockWithDotImports)(nil).Method1))
+}
+
+// Method2 mocks base method
+func (m *MockWithDotImports) Method2() *bytes.Buffer {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Method2")
+	ret0, _ := ret[0].(*bytes.Buffer)
+	return ret0
+}
+
+// Method2 indicates an expected call of Method2
+func (mr *MockWithDotImportsMockRecorder) Method2() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Method2", reflect.TypeOf((*MockWithDotImports)(nil).Method2))
+}
+
+// Method3 mocks base method
+func (m *MockWithDotImports) Method3() context.Context {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Method3")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Method3 indicates an expected call of Method3
+func (mr *MockWithDotImportsMockRecorder) Method3() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Method3", reflect.TypeOf((*MockWithDotImports)(nil).Method3))
+}
+
+// MockWithDotImportedEmbed is a mock of WithDotImportedEmbed interface
+type MockWithDotImportedEmbed struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockWithDotImportedEmbedMockRecorder
+}
+
+// MockWithDotImportedEmbedMockRecorder is the mock recorder for MockWithDotImportedEmbed
+type MockWithDotImportedEmbedMockRecorder struct {
+	mock *MockWithDotImportedEmbed
+}
+
+// NewMockWithDotImportedEmbed creates a new mock instance
+func NewMockWithDotImportedEmbed(ctrl gomock.ControllerInterface) *MockWithDotImportedEmbed {
+	mock := &MockWithDotImportedEmbed{ctrl: ctrl}
+	mock.recorder = &MockWithDotImportedEmbedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockWithDotImportedEmbed) EXPECT() *MockWithDotImportedEmbedMockRecorder {
+	return m.recorder
+}
+
+// Deadline mocks base method
+func (m *MockWithDotImportedEmbed) Deadline() (deadline time.Time, ok bool) {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Deadline")
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Deadline indicates an expected call of Deadline
+func (mr *MockWithDotImportedEmbedMockRecorder) Deadline() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deadline", reflect.TypeOf((*MockWithDotImportedEmbed)(nil).Deadline))
+}
+
+// Done mocks base method
+func (m *MockWithDotImportedEmbed) Done() <-chan struct{} {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Done")
+	ret0, _ := ret[0].(<-chan struct{})
+	return ret0
+}
+
+// Done indicates an expected call of Done
+func (mr *MockWithDotImportedEmbedMockRecorder) Done() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Done", reflect.TypeOf((*MockWithDotImportedEmbed)(nil).Done))
+}
+
+// Err mocks base method
+func (m *MockWithDotImportedEmbed) Err() error {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Err")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Err indicates an expected call of Err
+func (mr *MockWithDotImportedEmbedMockRecorder) Err() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Err", reflect.TypeOf((*MockWithDotImportedEmbed)(nil).Err))
+}
+
+// Value mocks base method
+func (m *MockWithDotImportedEmbed) Value(key interface{}) interface{} {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Value", key)
+	ret0, _ := ret[0].(interface{})
+	return ret0
+}
+
+// Value indicates an expected call of Value
+func (mr *MockWithDotImportedEmbedMockRecorder) Value(key interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Value", reflect.TypeOf((*MockWithDotImportedEmbed)(nil).Value), key)
+}
@@ -0,0 +1,18 @@
+//go:generate mockgen -embed_origins -package embed_origins -destination mock.go -source input.go
+
+package embed_origins
+
+import "io"
+
+// Writer is embedded in Stream from this package.
+type Writer interface {
+	Write(p []byte) (int, error)
+}
+
+// Stream is composed of several embedded interfaces, one of which embeds
+// others in turn.
+type Stream interface {
+	io.ReadCloser
+	Writer
+	Flush() error
+}
@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+
+// Package embed_origins is a generated GoMock package.
+package embed_origins
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockWriter is a mock of Writer interface
+type MockWriter struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockWriterMockRecorder
+}
+
+// MockWriterMockRecorder is the mock recorder for MockWriter
+type MockWriterMockRecorder struct {
+	mock *MockWriter
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ Writer = (*MockWriter)(nil)
+
+// NewMockWriter creates a new mock instance
+func NewMockWriter(ctrl gomock.ControllerInterface) *MockWriter {
+	mock := &MockWriter{ctrl: ctrl}
+	mock.recorder = &MockWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockWriter) EXPECT() *MockWriterMockRecorder {
+	return m.recorder
+}
+
+// Write mocks base method
+func (m *MockWriter) Write(p []byte) (int, error) {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Write", p)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Write indicates an expected call of Write
+func (mr *MockWriterMockRecorder) Write(p interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockWriter)(nil).Write), p)
+}
+
+// MockStream is a mock of Stream interface
+type MockStream struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockStreamMockRecorder
+}
+
+// MockStreamMockRecorder is the mock recorder for MockStream
+type MockStreamMockRecorder struct {
+	mock *MockStream
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ Stream = (*MockStream)(nil)
+
+// NewMockStream creates a new mock instance
+func NewMockStream(ctrl gomock.ControllerInterface) *MockStream {
+	mock := &MockStream{ctrl: ctrl}
+	mock.recorder = &MockStreamMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStream) EXPECT() *MockStreamMockRecorder {
+	return m.recorder
+}
+
+// Read mocks base method
+// from io.ReadCloser
+func (m *MockStream) Read(p []byte) (n int, err error) {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Read", p)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read
+func (mr *MockStreamMockRecorder) Read(p interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockStream)(nil).Read), p)
+}
+
+// Close mocks base method
+// from io.ReadCloser
+func (m *MockStream) Close() error {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close
+func (mr *MockStreamMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockStream)(nil).Close))
+}
+
+// Write mocks base method
+// from Writer
+func (m *MockStream) Write(p []byte) (int, error) {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Write", p)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Write indicates an expected call of Write
+func (mr *MockStreamMockRecorder) Write(p interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockStream)(nil).Write), p)
+}
+
+// Flush mocks base method
+func (m *MockStream) Flush() error {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Flush")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush
+func (mr *MockStreamMockRecorder) Flush() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockStream)(nil).Flush))
+}
@@ -10,7 +10,7 @@ import (
 
 // MockEmpty is a mock of Empty interface
 type MockEmpty struct {
-	ctrl     *gomock.Controller
+	ctrl     gomock.ControllerInterface
 	recorder *MockEmptyMockRecorder
 }
 
@@ -19,8 +19,11 @@ type MockEmptyMockRecorder struct {
 	mock *MockEmpty
 }
 
+// Verify that the mock satisfies the interface at compile time.
+var _ Empty = (*MockEmpty)(nil)
+
 // NewMockEmpty creates a new mock instance
-func NewMockEmpty(ctrl *gomock.Controller) *MockEmpty {
+func NewMockEmpty(ctrl gomock.ControllerInterface) *MockEmpty {
 	mock := &MockEmpty{ctrl: ctrl}
 	mock.recorder = &MockEmptyMockRecorder{mock}
 	return mock
@@ -11,7 +11,7 @@ import (
 
 // MockExample is a mock of Example interface
 type MockExample struct {
-	ctrl     *gomock.Controller
+	ctrl     gomock.ControllerInterface
 	recorder *MockExampleMockRecorder
 }
 
@@ -20,8 +20,11 @@ type MockExampleMockRecorder struct {
 	mock *MockExample
 }
 
+// Verify that the mock satisfies the interface at compile time.
+var _ Example = (*MockExample)(nil)
+
 // NewMockExample creates a new mock instance
-func NewMockExample(ctrl *gomock.Controller) *MockExample {
+func NewMockExample(ctrl gomock.ControllerInterface) *MockExample {
 	mock := &MockExample{ctrl: ctrl}
 	mock.recorder = &MockExampleMockRecorder{mock}
 	return mock
@@ -34,19 +37,19 @@ func (m *MockExample) EXPECT() *MockExampleMockRecorder {
 
 // Method mocks base method
 func (m_2 *MockExample) Method(_m, _mr, m, mr int) {
-	m_2.ctrl.T.Helper()
+	m_2.ctrl.TestHelper().Helper()
 	m_2.ctrl.Call(m_2, "Method", _m, _mr, m, mr)
 }
 
 // Method indicates an expected call of Method
 func (mr_2 *MockExampleMockRecorder) Method(_m, _mr, m, mr interface{}) *gomock.Call {
-	mr_2.mock.ctrl.T.Helper()
+	mr_2.mock.ctrl.TestHelper().Helper()
 	return mr_2.mock.ctrl.RecordCallWithMethodType(mr_2.mock, "Method", reflect.TypeOf((*MockExample)(nil).Method), _m, _mr, m, mr)
 }
 
 // VarargMethod mocks base method
 func (m *MockExample) VarargMethod(_s, _x, a, ret int, varargs ...int) {
-	m.ctrl.T.Helper()
+	m.ctrl.TestHelper().Helper()
 	varargs_2 := []interface{}{_s, _x, a, ret}
 	for _, a_2 := range varargs {
 		varargs_2 = append(varargs_2, a_2)
@@ -56,7 +59,7 @@ func (m *MockExample) VarargMethod(_s, _x, a, ret int, varargs ...int) {
 
 // VarargMethod indicates an expected call of VarargMethod
 func (mr *MockExampleMockRecorder) VarargMethod(_s, _x, a, ret interface{}, varargs ...interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
+	mr.mock.ctrl.TestHelper().Helper()
 	varargs_2 := append([]interface{}{_s, _x, a, ret}, varargs...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VarargMethod", reflect.TypeOf((*MockExample)(nil).VarargMethod), varargs_2...)
 }
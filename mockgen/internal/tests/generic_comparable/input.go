@@ -0,0 +1,27 @@
+//go:build go1.18
+// +build go1.18
+
+//go:generate mockgen -destination mock.go -package generic_comparable -source input.go
+
+package generic_comparable
+
+// Set is a set of comparable values. Its mock must carry the comparable
+// constraint, since implementations typically keep the values in a map.
+type Set[T comparable] interface {
+	Add(T)
+	Has(T) bool
+	Union(other Set[T]) Set[T]
+}
+
+// AddNew adds the values that s does not have yet, and returns how many it
+// added.
+func AddNew[T comparable](s Set[T], vals ...T) int {
+	n := 0
+	for _, v := range vals {
+		if !s.Has(v) {
+			s.Add(v)
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,42 @@
+//go:build go1.18
+// +build go1.18
+
+package generic_comparable
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestAddNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := NewMockSet[int](ctrl)
+	s.EXPECT().Has(1).Return(true)
+	s.EXPECT().Has(2).Return(false)
+	s.EXPECT().Add(2)
+
+	if n := AddNew[int](s, 1, 2); n != 1 {
+		t.Errorf("AddNew(1, 2) = %d, want 1", n)
+	}
+}
+
+func TestUnion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	a, b, union := NewMockSet[int](ctrl), NewMockSet[int](ctrl), NewMockSet[int](ctrl)
+	a.EXPECT().Union(b).Return(union)
+	union.EXPECT().Has(3).Return(true)
+
+	var s Set[int] = a
+	got := s.Union(b)
+	if got != union {
+		t.Errorf("Union(b) = %v, want %v", got, union)
+	}
+	if !got.Has(3) {
+		t.Error("Has(3) = false, want true")
+	}
+}
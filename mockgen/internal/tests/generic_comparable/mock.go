@@ -0,0 +1,79 @@
+//go:build go1.18
+// +build go1.18
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+
+// Package generic_comparable is a generated GoMock package.
+package generic_comparable
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockSet is a mock of Set interface
+type MockSet[T comparable] struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockSetMockRecorder[T]
+}
+
+// MockSetMockRecorder is the mock recorder for MockSet
+type MockSetMockRecorder[T comparable] struct {
+	mock *MockSet[T]
+}
+
+// Verify that the mock satisfies the interface at compile time.
+func _[T comparable]() { var _ Set[T] = (*MockSet[T])(nil) }
+
+// NewMockSet creates a new mock instance
+func NewMockSet[T comparable](ctrl gomock.ControllerInterface) *MockSet[T] {
+	mock := &MockSet[T]{ctrl: ctrl}
+	mock.recorder = &MockSetMockRecorder[T]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSet[T]) EXPECT() *MockSetMockRecorder[T] {
+	return m.recorder
+}
+
+// Add mocks base method
+func (m *MockSet[T]) Add(arg0 T) {
+	m.ctrl.TestHelper().Helper()
+	m.ctrl.Call(m, "Add", arg0)
+}
+
+// Add indicates an expected call of Add
+func (mr *MockSetMockRecorder[T]) Add(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockSet[T])(nil).Add), arg0)
+}
+
+// Has mocks base method
+func (m *MockSet[T]) Has(arg0 T) bool {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Has", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Has indicates an expected call of Has
+func (mr *MockSetMockRecorder[T]) Has(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Has", reflect.TypeOf((*MockSet[T])(nil).Has), arg0)
+}
+
+// Union mocks base method
+func (m *MockSet[T]) Union(other Set[T]) Set[T] {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Union", other)
+	ret0, _ := ret[0].(Set[T])
+	return ret0
+}
+
+// Union indicates an expected call of Union
+func (mr *MockSetMockRecorder[T]) Union(other interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Union", reflect.TypeOf((*MockSet[T])(nil).Union), other)
+}
@@ -11,7 +11,7 @@ import (
 
 // MockS is a mock of S interface
 type MockS struct {
-	ctrl     *gomock.Controller
+	ctrl     gomock.ControllerInterface
 	recorder *MockSMockRecorder
 }
 
@@ -20,8 +20,11 @@ type MockSMockRecorder struct {
 	mock *MockS
 }
 
+// Verify that the mock satisfies the interface at compile time.
+var _ S = (*MockS)(nil)
+
 // NewMockS creates a new mock instance
-func NewMockS(ctrl *gomock.Controller) *MockS {
+func NewMockS(ctrl gomock.ControllerInterface) *MockS {
 	mock := &MockS{ctrl: ctrl}
 	mock.recorder = &MockSMockRecorder{mock}
 	return mock
@@ -34,12 +37,12 @@ func (m *MockS) EXPECT() *MockSMockRecorder {
 
 // F mocks base method
 func (m *MockS) F(arg0 X) {
-	m.ctrl.T.Helper()
+	m.ctrl.TestHelper().Helper()
 	m.ctrl.Call(m, "F", arg0)
 }
 
 // F indicates an expected call of F
 func (mr *MockSMockRecorder) F(arg0 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
+	mr.mock.ctrl.TestHelper().Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "F", reflect.TypeOf((*MockS)(nil).F), arg0)
 }
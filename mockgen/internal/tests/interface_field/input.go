@@ -0,0 +1,23 @@
+//go:generate mockgen -destination mock/mock.go -source input.go
+
+package interface_field
+
+// Entry is a log entry.
+type Entry struct {
+	Msg string
+}
+
+// Logger is used through an interface-typed field of Service.
+type Logger interface {
+	Log(e Entry)
+}
+
+// Service logs through its Logger field.
+type Service struct {
+	Logger Logger
+}
+
+// Run logs that the service ran.
+func (s *Service) Run() {
+	s.Logger.Log(Entry{Msg: "run"})
+}
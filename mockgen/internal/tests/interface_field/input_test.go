@@ -0,0 +1,20 @@
+package interface_field_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/mock/mockgen/internal/tests/interface_field"
+	"github.com/golang/mock/mockgen/internal/tests/interface_field/mock"
+)
+
+func TestServiceRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := mock_interface_field.NewMockLogger(ctrl)
+	logger.EXPECT().Log(interface_field.Entry{Msg: "run"})
+
+	s := &interface_field.Service{Logger: logger}
+	s.Run()
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+
+// Package mock_interface_field is a generated GoMock package.
+package mock_interface_field
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	interface_field "github.com/golang/mock/mockgen/internal/tests/interface_field"
+	reflect "reflect"
+)
+
+// MockLogger is a mock of Logger interface
+type MockLogger struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockLoggerMockRecorder
+}
+
+// MockLoggerMockRecorder is the mock recorder for MockLogger
+type MockLoggerMockRecorder struct {
+	mock *MockLogger
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ interface_field.Logger = (*MockLogger)(nil)
+
+// NewMockLogger creates a new mock instance
+func NewMockLogger(ctrl gomock.ControllerInterface) *MockLogger {
+	mock := &MockLogger{ctrl: ctrl}
+	mock.recorder = &MockLoggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockLogger) EXPECT() *MockLoggerMockRecorder {
+	return m.recorder
+}
+
+// Log mocks base method
+func (m *MockLogger) Log(e interface_field.Entry) {
+	m.ctrl.TestHelper().Helper()
+	m.ctrl.Call(m, "Log", e)
+}
+
+// Log indicates an expected call of Log
+func (mr *MockLoggerMockRecorder) Log(e interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Log", reflect.TypeOf((*MockLogger)(nil).Log), e)
+}
@@ -0,0 +1,23 @@
+//go:generate mockgen -internal -source input.go -destination mock_test.go
+
+package internal_mock
+
+// entry is an unexported type that the mock must refer to unqualified.
+type entry struct {
+	key, value string
+}
+
+// Store uses unexported types in its method signatures.
+type Store interface {
+	Get(key string) (*entry, error)
+	Scan(prefix string) []entry
+}
+
+// lookup reads the value of key from s.
+func lookup(s Store, key string) (string, error) {
+	e, err := s.Get(key)
+	if err != nil {
+		return "", err
+	}
+	return e.value, nil
+}
@@ -0,0 +1,19 @@
+package internal_mock
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestLookup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockStore(ctrl)
+	m.EXPECT().Get("a").Return(&entry{key: "a", value: "1"}, nil)
+
+	if got, err := lookup(m, "a"); err != nil || got != "1" {
+		t.Errorf("lookup() = %q, %v, want %q, nil", got, err, "1")
+	}
+}
@@ -12,7 +12,7 @@ import (
 
 // MockIntf is a mock of Intf interface
 type MockIntf struct {
-	ctrl     *gomock.Controller
+	ctrl     gomock.ControllerInterface
 	recorder *MockIntfMockRecorder
 }
 
@@ -21,8 +21,11 @@ type MockIntfMockRecorder struct {
 	mock *MockIntf
 }
 
+// Verify that the mock satisfies the interface at compile time.
+var _ pkg.Intf = (*MockIntf)(nil)
+
 // NewMockIntf creates a new mock instance
-func NewMockIntf(ctrl *gomock.Controller) *MockIntf {
+func NewMockIntf(ctrl gomock.ControllerInterface) *MockIntf {
 	mock := &MockIntf{ctrl: ctrl}
 	mock.recorder = &MockIntfMockRecorder{mock}
 	return mock
@@ -35,7 +38,7 @@ func (m *MockIntf) EXPECT() *MockIntfMockRecorder {
 
 // F mocks base method
 func (m *MockIntf) F() pkg.Arg {
-	m.ctrl.T.Helper()
+	m.ctrl.TestHelper().Helper()
 	ret := m.ctrl.Call(m, "F")
 	ret0, _ := ret[0].(pkg.Arg)
 	return ret0
@@ -43,6 +46,6 @@ func (m *MockIntf) F() pkg.Arg {
 
 // F indicates an expected call of F
 func (mr *MockIntfMockRecorder) F() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
+	mr.mock.ctrl.TestHelper().Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "F", reflect.TypeOf((*MockIntf)(nil).F))
 }
@@ -12,7 +12,7 @@ import (
 
 // MockArg is a mock of Arg interface
 type MockArg struct {
-	ctrl     *gomock.Controller
+	ctrl     gomock.ControllerInterface
 	recorder *MockArgMockRecorder
 }
 
@@ -21,8 +21,11 @@ type MockArgMockRecorder struct {
 	mock *MockArg
 }
 
+// Verify that the mock satisfies the interface at compile time.
+var _ pkg.Arg = (*MockArg)(nil)
+
 // NewMockArg creates a new mock instance
-func NewMockArg(ctrl *gomock.Controller) *MockArg {
+func NewMockArg(ctrl gomock.ControllerInterface) *MockArg {
 	mock := &MockArg{ctrl: ctrl}
 	mock.recorder = &MockArgMockRecorder{mock}
 	return mock
@@ -35,7 +38,7 @@ func (m *MockArg) EXPECT() *MockArgMockRecorder {
 
 // Foo mocks base method
 func (m *MockArg) Foo() int {
-	m.ctrl.T.Helper()
+	m.ctrl.TestHelper().Helper()
 	ret := m.ctrl.Call(m, "Foo")
 	ret0, _ := ret[0].(int)
 	return ret0
@@ -43,13 +46,13 @@ func (m *MockArg) Foo() int {
 
 // Foo indicates an expected call of Foo
 func (mr *MockArgMockRecorder) Foo() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
+	mr.mock.ctrl.TestHelper().Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Foo", reflect.TypeOf((*MockArg)(nil).Foo))
 }
 
 // MockIntf is a mock of Intf interface
 type MockIntf struct {
-	ctrl     *gomock.Controller
+	ctrl     gomock.ControllerInterface
 	recorder *MockIntfMockRecorder
 }
 
@@ -58,8 +61,11 @@ type MockIntfMockRecorder struct {
 	mock *MockIntf
 }
 
+// Verify that the mock satisfies the interface at compile time.
+var _ pkg.Intf = (*MockIntf)(nil)
+
 // NewMockIntf creates a new mock instance
-func NewMockIntf(ctrl *gomock.Controller) *MockIntf {
+func NewMockIntf(ctrl gomock.ControllerInterface) *MockIntf {
 	mock := &MockIntf{ctrl: ctrl}
 	mock.recorder = &MockIntfMockRecorder{mock}
 	return mock
@@ -72,7 +78,7 @@ func (m *MockIntf) EXPECT() *MockIntfMockRecorder {
 
 // F mocks base method
 func (m *MockIntf) F() pkg.Arg {
-	m.ctrl.T.Helper()
+	m.ctrl.TestHelper().Helper()
 	ret := m.ctrl.Call(m, "F")
 	ret0, _ := ret[0].(pkg.Arg)
 	return ret0
@@ -80,6 +86,6 @@ func (m *MockIntf) F() pkg.Arg {
 
 // F indicates an expected call of F
 func (mr *MockIntfMockRecorder) F() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
+	mr.mock.ctrl.TestHelper().Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "F", reflect.TypeOf((*MockIntf)(nil).F))
 }
@@ -0,0 +1,18 @@
+//go:generate mockgen -json_stubs -package json_stubs -destination mock.go -source input.go
+
+package json_stubs
+
+// User is a record returned by Directory.
+type User struct {
+	Name  string   `json:"name"`
+	Admin bool     `json:"admin"`
+	Teams []string `json:"teams"`
+}
+
+// Directory is mocked with a LoadFromJSON method.
+type Directory interface {
+	Lookup(id int) (*User, error)
+	List(teams ...string) ([]User, error)
+	Count() int
+	Delete(id int) error
+}
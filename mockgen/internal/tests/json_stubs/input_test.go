@@ -0,0 +1,33 @@
+package json_stubs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestLoadFromJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := NewMockDirectory(ctrl)
+	if err := dir.LoadFromJSON("testdata/directory.json"); err != nil {
+		t.Fatalf("LoadFromJSON() error = %v", err)
+	}
+
+	user, err := dir.Lookup(7)
+	if want := (&User{Name: "gopher", Admin: true, Teams: []string{"go"}}); err != nil || !reflect.DeepEqual(user, want) {
+		t.Errorf("Lookup() = %+v, %v, want %+v, nil", user, err, want)
+	}
+	users, err := dir.List("go", "rust")
+	if want := []User{{Name: "gopher"}, {Name: "rustacean"}}; err != nil || !reflect.DeepEqual(users, want) {
+		t.Errorf("List() = %+v, %v, want %+v, nil", users, err, want)
+	}
+	if n := dir.Count(); n != 2 {
+		t.Errorf("Count() = %d, want 2", n)
+	}
+	if err := dir.Delete(7); err == nil || err.Error() != "permission denied" {
+		t.Errorf("Delete() error = %v, want permission denied", err)
+	}
+}
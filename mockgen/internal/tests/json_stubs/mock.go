@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+
+// Package json_stubs is a generated GoMock package.
+package json_stubs
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockDirectory is a mock of Directory interface
+type MockDirectory struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockDirectoryMockRecorder
+}
+
+// MockDirectoryMockRecorder is the mock recorder for MockDirectory
+type MockDirectoryMockRecorder struct {
+	mock *MockDirectory
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ Directory = (*MockDirectory)(nil)
+
+// NewMockDirectory creates a new mock instance
+func NewMockDirectory(ctrl gomock.ControllerInterface) *MockDirectory {
+	mock := &MockDirectory{ctrl: ctrl}
+	mock.recorder = &MockDirectoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockDirectory) EXPECT() *MockDirectoryMockRecorder {
+	return m.recorder
+}
+
+// LoadFromJSON sets up the mock to return the values in the JSON fixture at
+// path; see gomock.LoadReturnsFromJSON for its format
+func (m *MockDirectory) LoadFromJSON(path string) error {
+	return gomock.LoadReturnsFromJSON(m, m.recorder, path)
+}
+
+// Lookup mocks base method
+func (m *MockDirectory) Lookup(id int) (*User, error) {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Lookup", id)
+	ret0, _ := ret[0].(*User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lookup indicates an expected call of Lookup
+func (mr *MockDirectoryMockRecorder) Lookup(id interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MockDirectory)(nil).Lookup), id)
+}
+
+// List mocks base method
+func (m *MockDirectory) List(teams ...string) ([]User, error) {
+	m.ctrl.TestHelper().Helper()
+	varargs := []interface{}{}
+	for _, a := range teams {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].([]User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List
+func (mr *MockDirectoryMockRecorder) List(teams ...interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDirectory)(nil).List), teams...)
+}
+
+// Count mocks base method
+func (m *MockDirectory) Count() int {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Count")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Count indicates an expected call of Count
+func (mr *MockDirectoryMockRecorder) Count() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockDirectory)(nil).Count))
+}
+
+// Delete mocks base method
+func (m *MockDirectory) Delete(id int) error {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockDirectoryMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDirectory)(nil).Delete), id)
+}
@@ -0,0 +1,27 @@
+//go:generate mockgen -destination mock_source_test.go -source main.go
+//go:generate mockgen -destination mock_reflect_test.go -mock_names Greeter=MockReflectGreeter github.com/golang/mock/mockgen/internal/tests/main_package Greeter
+
+// Command main_package greets a person.
+package main
+
+import "fmt"
+
+// Person is greeted by a Greeter.
+type Person struct {
+	Name string
+}
+
+// Greeter is an interface of a main package.
+type Greeter interface {
+	Greet(p Person) string
+}
+
+type english struct{}
+
+func (english) Greet(p Person) string { return "hello, " + p.Name }
+
+func greetGopher(g Greeter) string { return g.Greet(Person{Name: "gopher"}) }
+
+func main() {
+	fmt.Println(greetGopher(english{}))
+}
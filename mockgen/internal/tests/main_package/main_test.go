@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestGreetGopher(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	source := NewMockGreeter(ctrl)
+	source.EXPECT().Greet(Person{Name: "gopher"}).Return("hi from source mode")
+	if got, want := greetGopher(source), "hi from source mode"; got != want {
+		t.Errorf("greetGopher() = %q, want %q", got, want)
+	}
+
+	reflected := NewMockReflectGreeter(ctrl)
+	reflected.EXPECT().Greet(Person{Name: "gopher"}).Return("hi from reflect mode")
+	if got, want := greetGopher(reflected), "hi from reflect mode"; got != want {
+		t.Errorf("greetGopher() = %q, want %q", got, want)
+	}
+}
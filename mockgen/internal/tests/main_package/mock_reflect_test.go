@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/golang/mock/mockgen/internal/tests/main_package (interfaces: Greeter)
+
+package main
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockReflectGreeter is a mock of Greeter interface
+type MockReflectGreeter struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockReflectGreeterMockRecorder
+}
+
+// MockReflectGreeterMockRecorder is the mock recorder for MockReflectGreeter
+type MockReflectGreeterMockRecorder struct {
+	mock *MockReflectGreeter
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ Greeter = (*MockReflectGreeter)(nil)
+
+// NewMockReflectGreeter creates a new mock instance
+func NewMockReflectGreeter(ctrl gomock.ControllerInterface) *MockReflectGreeter {
+	mock := &MockReflectGreeter{ctrl: ctrl}
+	mock.recorder = &MockReflectGreeterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockReflectGreeter) EXPECT() *MockReflectGreeterMockRecorder {
+	return m.recorder
+}
+
+// Greet mocks base method
+func (m *MockReflectGreeter) Greet(arg0 Person) string {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Greet", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Greet indicates an expected call of Greet
+func (mr *MockReflectGreeterMockRecorder) Greet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Greet", reflect.TypeOf((*MockReflectGreeter)(nil).Greet), arg0)
+}
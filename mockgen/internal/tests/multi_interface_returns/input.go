@@ -0,0 +1,10 @@
+//go:generate mockgen -destination mock.go -package multi_interface_returns -source input.go
+
+package multi_interface_returns
+
+import "io"
+
+// Opener has a method returning several interfaces.
+type Opener interface {
+	Open(name string) (io.Reader, io.Closer, error)
+}
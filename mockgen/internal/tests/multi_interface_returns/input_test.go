@@ -0,0 +1,85 @@
+package multi_interface_returns
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+type closer struct {
+	closed bool
+}
+
+func (c *closer) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestOpen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockOpener(ctrl)
+	c := new(closer)
+	m.EXPECT().Open("a").Return(strings.NewReader("contents"), c, nil)
+	m.EXPECT().Open("b").Return(nil, nil, io.ErrUnexpectedEOF)
+
+	r, cl, err := m.Open("a")
+	if err != nil {
+		t.Fatalf("Open(a) error = %v", err)
+	}
+	if b, _ := ioutil.ReadAll(r); string(b) != "contents" {
+		t.Errorf("read %q, want %q", b, "contents")
+	}
+	if cl.Close(); !c.closed {
+		t.Error("returned closer was not the one given to Return")
+	}
+
+	if r, cl, err := m.Open("b"); r != nil || cl != nil || err != io.ErrUnexpectedEOF {
+		t.Errorf("Open(b) = %v, %v, %v, want nil, nil, %v", r, cl, err, io.ErrUnexpectedEOF)
+	}
+}
+
+// fatalReporter records the message of a fatal failure and stops the
+// calling function by panicking.
+type fatalReporter struct {
+	msg string
+}
+
+type fatalPanic struct{}
+
+func (r *fatalReporter) Errorf(format string, args ...interface{}) {}
+
+func (r *fatalReporter) Fatalf(format string, args ...interface{}) {
+	r.msg = fmt.Sprintf(format, args...)
+	panic(fatalPanic{})
+}
+
+func TestOpen_NotImplemented(t *testing.T) {
+	for _, tc := range []struct {
+		rets []interface{}
+		want string
+	}{
+		{[]interface{}{"contents", new(closer), nil}, "argument 0 to Return for *multi_interface_returns.MockOpener.Open: string is not assignable to io.Reader"},
+		{[]interface{}{strings.NewReader(""), closer{}, nil}, "argument 1 to Return for *multi_interface_returns.MockOpener.Open: multi_interface_returns.closer is not assignable to io.Closer"},
+		{[]interface{}{strings.NewReader(""), new(closer), "failed"}, "argument 2 to Return for *multi_interface_returns.MockOpener.Open: string is not assignable to error"},
+	} {
+		reporter := new(fatalReporter)
+		m := NewMockOpener(gomock.NewController(reporter))
+		func() {
+			defer func() {
+				if r := recover(); r != nil && r != (fatalPanic{}) {
+					panic(r)
+				}
+			}()
+			m.EXPECT().Open("a").Return(tc.rets...)
+		}()
+		if !strings.Contains(reporter.msg, tc.want) {
+			t.Errorf("failure = %q, want to contain %q", reporter.msg, tc.want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+
+// Package multi_interface_returns is a generated GoMock package.
+package multi_interface_returns
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	io "io"
+	reflect "reflect"
+)
+
+// MockOpener is a mock of Opener interface
+type MockOpener struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockOpenerMockRecorder
+}
+
+// MockOpenerMockRecorder is the mock recorder for MockOpener
+type MockOpenerMockRecorder struct {
+	mock *MockOpener
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ Opener = (*MockOpener)(nil)
+
+// NewMockOpener creates a new mock instance
+func NewMockOpener(ctrl gomock.ControllerInterface) *MockOpener {
+	mock := &MockOpener{ctrl: ctrl}
+	mock.recorder = &MockOpenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockOpener) EXPECT() *MockOpenerMockRecorder {
+	return m.recorder
+}
+
+// Open mocks base method
+func (m *MockOpener) Open(name string) (io.Reader, io.Closer, error) {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Open", name)
+	ret0, _ := ret[0].(io.Reader)
+	ret1, _ := ret[1].(io.Closer)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Open indicates an expected call of Open
+func (mr *MockOpenerMockRecorder) Open(name interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockOpener)(nil).Open), name)
+}
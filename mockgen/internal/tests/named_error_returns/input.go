@@ -0,0 +1,24 @@
+//go:generate mockgen -destination mock.go -package named_error_returns -source input.go
+
+package named_error_returns
+
+import "fmt"
+
+// Result is returned on success.
+type Result struct {
+	ID string
+}
+
+// APIError is a custom error type returned by pointer.
+type APIError struct {
+	Code int
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api error %d", e.Code)
+}
+
+// Client returns a concrete error type rather than error.
+type Client interface {
+	Fetch(id string) (Result, *APIError)
+}
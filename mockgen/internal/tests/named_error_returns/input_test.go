@@ -0,0 +1,64 @@
+package named_error_returns
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestFetch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockClient(ctrl)
+	m.EXPECT().Fetch("untyped").Return(Result{ID: "a"}, nil)
+	m.EXPECT().Fetch("typed").Return(Result{ID: "b"}, (*APIError)(nil))
+	m.EXPECT().Fetch("missing").Return(Result{}, &APIError{Code: 404})
+
+	for _, id := range []string{"untyped", "typed"} {
+		res, err := m.Fetch(id)
+		if err != nil {
+			t.Errorf("Fetch(%q) error = %v, want nil", id, err)
+		}
+		if res.ID == "" {
+			t.Errorf("Fetch(%q) returned an empty result", id)
+		}
+	}
+	if _, err := m.Fetch("missing"); err == nil || err.Code != 404 {
+		t.Errorf("Fetch(missing) error = %v, want api error 404", err)
+	}
+}
+
+// fatalReporter records the message of a fatal failure and stops the
+// calling function by panicking.
+type fatalReporter struct {
+	msg string
+}
+
+type fatalPanic struct{}
+
+func (r *fatalReporter) Errorf(format string, args ...interface{}) {}
+
+func (r *fatalReporter) Fatalf(format string, args ...interface{}) {
+	r.msg = fmt.Sprintf(format, args...)
+	panic(fatalPanic{})
+}
+
+func TestFetch_PlainError(t *testing.T) {
+	reporter := new(fatalReporter)
+	m := NewMockClient(gomock.NewController(reporter))
+	func() {
+		defer func() {
+			if r := recover(); r != nil && r != (fatalPanic{}) {
+				panic(r)
+			}
+		}()
+		m.EXPECT().Fetch("a").Return(Result{}, errors.New("failed"))
+	}()
+	if want := "*errors.errorString is not assignable to *named_error_returns.APIError"; !strings.Contains(reporter.msg, want) {
+		t.Errorf("failure = %q, want to contain %q", reporter.msg, want)
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+
+// Package named_error_returns is a generated GoMock package.
+package named_error_returns
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockClient is a mock of Client interface
+type MockClient struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ Client = (*MockClient)(nil)
+
+// NewMockClient creates a new mock instance
+func NewMockClient(ctrl gomock.ControllerInterface) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// Fetch mocks base method
+func (m *MockClient) Fetch(id string) (Result, *APIError) {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Fetch", id)
+	ret0, _ := ret[0].(Result)
+	ret1, _ := ret[1].(*APIError)
+	return ret0, ret1
+}
+
+// Fetch indicates an expected call of Fetch
+func (mr *MockClientMockRecorder) Fetch(id interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fetch", reflect.TypeOf((*MockClient)(nil).Fetch), id)
+}
@@ -0,0 +1,12 @@
+//go:generate mockgen -package named_returns -destination mock.go -source input.go
+package named_returns
+
+import "io"
+
+type NamedReturns interface {
+	Read(p []byte) (n int, err error)
+	Open(name string) (r io.Reader, _ error)
+	Pair() (x, y int)
+	// ret and m are also used as identifiers by the generated code.
+	Conflicting(m string) (ret string)
+}
@@ -0,0 +1,23 @@
+package named_returns
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNamedReturns(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockNamedReturns(ctrl)
+	m.EXPECT().Pair().DoAndReturn(func() (x, y int) { return 1, 2 })
+	m.EXPECT().Conflicting("m").Return("ret")
+
+	if x, y := m.Pair(); x != 1 || y != 2 {
+		t.Errorf("Pair() = %d, %d, want 1, 2", x, y)
+	}
+	if got := m.Conflicting("m"); got != "ret" {
+		t.Errorf("Conflicting() = %q, want %q", got, "ret")
+	}
+}
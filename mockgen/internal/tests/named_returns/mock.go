@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+
+// Package named_returns is a generated GoMock package.
+package named_returns
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	io "io"
+	reflect "reflect"
+)
+
+// MockNamedReturns is a mock of NamedReturns interface
+type MockNamedReturns struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockNamedReturnsMockRecorder
+}
+
+// MockNamedReturnsMockRecorder is the mock recorder for MockNamedReturns
+type MockNamedReturnsMockRecorder struct {
+	mock *MockNamedReturns
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ NamedReturns = (*MockNamedReturns)(nil)
+
+// NewMockNamedReturns creates a new mock instance
+func NewMockNamedReturns(ctrl gomock.ControllerInterface) *MockNamedReturns {
+	mock := &MockNamedReturns{ctrl: ctrl}
+	mock.recorder = &MockNamedReturnsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockNamedReturns) EXPECT() *MockNamedReturnsMockRecorder {
+	return m.recorder
+}
+
+// Read mocks base method
+func (m *MockNamedReturns) Read(p []byte) (n int, err error) {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Read", p)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read
+func (mr *MockNamedReturnsMockRecorder) Read(p interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockNamedReturns)(nil).Read), p)
+}
+
+// Open mocks base method
+func (m *MockNamedReturns) Open(name string) (r io.Reader, _ error) {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Open", name)
+	ret0, _ := ret[0].(io.Reader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open
+func (mr *MockNamedReturnsMockRecorder) Open(name interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockNamedReturns)(nil).Open), name)
+}
+
+// Pair mocks base method
+func (m *MockNamedReturns) Pair() (x, y int) {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Pair")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	return ret0, ret1
+}
+
+// Pair indicates an expected call of Pair
+func (mr *MockNamedReturnsMockRecorder) Pair() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pair", reflect.TypeOf((*MockNamedReturns)(nil).Pair))
+}
+
+// Conflicting mocks base method
+func (m_2 *MockNamedReturns) Conflicting(m string) (ret string) {
+	m_2.ctrl.TestHelper().Helper()
+	ret_2 := m_2.ctrl.Call(m_2, "Conflicting", m)
+	ret0, _ := ret_2[0].(string)
+	return ret0
+}
+
+// Conflicting indicates an expected call of Conflicting
+func (mr *MockNamedReturnsMockRecorder) Conflicting(m interface{}) *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Conflicting", reflect.TypeOf((*MockNamedReturns)(nil).Conflicting), m)
+}
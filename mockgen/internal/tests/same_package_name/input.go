@@ -0,0 +1,8 @@
+//go:generate mockgen -source=input.go -destination=mocks/mock.go -package=same_package_name
+
+package same_package_name
+
+// Getter gets a value.
+type Getter interface {
+	Get() int
+}
@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+
+// Package same_package_name is a generated GoMock package.
+package same_package_name
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockGetter is a mock of Getter interface
+type MockGetter struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockGetterMockRecorder
+}
+
+// MockGetterMockRecorder is the mock recorder for MockGetter
+type MockGetterMockRecorder struct {
+	mock *MockGetter
+}
+
+// NewMockGetter creates a new mock instance
+func NewMockGetter(ctrl gomock.ControllerInterface) *MockGetter {
+	mock := &MockGetter{ctrl: ctrl}
+	mock.recorder = &MockGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockGetter) EXPECT() *MockGetterMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method
+func (m *MockGetter) Get() int {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Get")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Get indicates an expected call of Get
+func (mr *MockGetterMockRecorder) Get() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockGetter)(nil).Get))
+}
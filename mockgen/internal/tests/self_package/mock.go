@@ -11,7 +11,7 @@ import (
 
 // MockMethods is a mock of Methods interface
 type MockMethods struct {
-	ctrl     *gomock.Controller
+	ctrl     gomock.ControllerInterface
 	recorder *MockMethodsMockRecorder
 }
 
@@ -20,8 +20,11 @@ type MockMethodsMockRecorder struct {
 	mock *MockMethods
 }
 
+// Verify that the mock satisfies the interface at compile time.
+var _ Methods = (*MockMethods)(nil)
+
 // NewMockMethods creates a new mock instance
-func NewMockMethods(ctrl *gomock.Controller) *MockMethods {
+func NewMockMethods(ctrl gomock.ControllerInterface) *MockMethods {
 	mock := &MockMethods{ctrl: ctrl}
 	mock.recorder = &MockMethodsMockRecorder{mock}
 	return mock
@@ -34,7 +37,7 @@ func (m *MockMethods) EXPECT() *MockMethodsMockRecorder {
 
 // getInfo mocks base method
 func (m *MockMethods) getInfo() Info {
-	m.ctrl.T.Helper()
+	m.ctrl.TestHelper().Helper()
 	ret := m.ctrl.Call(m, "getInfo")
 	ret0, _ := ret[0].(Info)
 	return ret0
@@ -42,6 +45,6 @@ func (m *MockMethods) getInfo() Info {
 
 // getInfo indicates an expected call of getInfo
 func (mr *MockMethodsMockRecorder) getInfo() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
+	mr.mock.ctrl.TestHelper().Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getInfo", reflect.TypeOf((*MockMethods)(nil).getInfo))
 }
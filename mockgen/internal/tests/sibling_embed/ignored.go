@@ -0,0 +1,10 @@
+//go:build ignore
+// +build ignore
+
+package sibling_embed
+
+// Writer is excluded by its build constraint, so it does not replace the
+// Writer of writer.go.
+type Writer interface {
+	Delete(key string) error
+}
@@ -0,0 +1,10 @@
+//go:generate mockgen -destination mock.go -package sibling_embed -source input.go
+
+package sibling_embed
+
+// Store embeds interfaces declared in sibling files of this package.
+type Store interface {
+	Reader
+	Writer
+	Close() error
+}
@@ -0,0 +1,8 @@
+package sibling_embed
+
+import "io"
+
+// Reader is embedded by Store, which is declared in another file.
+type Reader interface {
+	Get(key string) (io.Reader, error)
+}
@@ -0,0 +1,6 @@
+package sibling_embed
+
+// Writer is embedded by Store, which is declared in another file.
+type Writer interface {
+	Put(key string, value []byte) error
+}
@@ -11,7 +11,7 @@ import (
 
 // MockFinder is a mock of Finder interface
 type MockFinder struct {
-	ctrl     *gomock.Controller
+	ctrl     gomock.ControllerInterface
 	recorder *MockFinderMockRecorder
 }
 
@@ -20,8 +20,11 @@ type MockFinderMockRecorder struct {
 	mock *MockFinder
 }
 
+// Verify that the mock satisfies the interface at compile time.
+var _ Finder = (*MockFinder)(nil)
+
 // NewMockFinder creates a new mock instance
-func NewMockFinder(ctrl *gomock.Controller) *MockFinder {
+func NewMockFinder(ctrl gomock.ControllerInterface) *MockFinder {
 	mock := &MockFinder{ctrl: ctrl}
 	mock.recorder = &MockFinderMockRecorder{mock}
 	return mock
@@ -34,7 +37,7 @@ func (m *MockFinder) EXPECT() *MockFinderMockRecorder {
 
 // FindUser mocks base method
 func (m *MockFinder) FindUser(name string) User {
-	m.ctrl.T.Helper()
+	m.ctrl.TestHelper().Helper()
 	ret := m.ctrl.Call(m, "FindUser", name)
 	ret0, _ := ret[0].(User)
 	return ret0
@@ -42,18 +45,18 @@ func (m *MockFinder) FindUser(name string) User {
 
 // FindUser indicates an expected call of FindUser
 func (mr *MockFinderMockRecorder) FindUser(name interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
+	mr.mock.ctrl.TestHelper().Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUser", reflect.TypeOf((*MockFinder)(nil).FindUser), name)
 }
 
 // Add mocks base method
 func (m *MockFinder) Add(u User) {
-	m.ctrl.T.Helper()
+	m.ctrl.TestHelper().Helper()
 	m.ctrl.Call(m, "Add", u)
 }
 
 // Add indicates an expected call of Add
 func (mr *MockFinderMockRecorder) Add(u interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
+	mr.mock.ctrl.TestHelper().Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockFinder)(nil).Add), u)
 }
@@ -11,7 +11,7 @@ import (
 
 // MockExample is a mock of Example interface
 type MockExample struct {
-	ctrl     *gomock.Controller
+	ctrl     gomock.ControllerInterface
 	recorder *MockExampleMockRecorder
 }
 
@@ -20,8 +20,11 @@ type MockExampleMockRecorder struct {
 	mock *MockExample
 }
 
+// Verify that the mock satisfies the interface at compile time.
+var _ Example = (*MockExample)(nil)
+
 // NewMockExample creates a new mock instance
-func NewMockExample(ctrl *gomock.Controller) *MockExample {
+func NewMockExample(ctrl gomock.ControllerInterface) *MockExample {
 	mock := &MockExample{ctrl: ctrl}
 	mock.recorder = &MockExampleMockRecorder{mock}
 	return mock
@@ -34,7 +37,7 @@ func (m *MockExample) EXPECT() *MockExampleMockRecorder {
 
 // someMethod mocks base method
 func (m *MockExample) someMethod(arg0 string) string {
-	m.ctrl.T.Helper()
+	m.ctrl.TestHelper().Helper()
 	ret := m.ctrl.Call(m, "someMethod", arg0)
 	ret0, _ := ret[0].(string)
 	return ret0
@@ -42,6 +45,6 @@ func (m *MockExample) someMethod(arg0 string) string {
 
 // someMethod indicates an expected call of someMethod
 func (mr *MockExampleMockRecorder) someMethod(arg0 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
+	mr.mock.ctrl.TestHelper().Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "someMethod", reflect.TypeOf((*MockExample)(nil).someMethod), arg0)
 }
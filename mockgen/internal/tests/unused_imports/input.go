@@ -0,0 +1,20 @@
+//go:generate mockgen -package unused_imports -destination mock.go -source input.go
+package unused_imports
+
+import (
+	"bytes"
+	. "context"
+	"fmt"
+	. "strings"
+)
+
+type WithUnusedImports interface {
+	Buffer() *bytes.Buffer
+	Context() Context
+}
+
+// shout is not part of an interface, so mockgen skips it along with the
+// packages it uses.
+func shout(s string) string {
+	return fmt.Sprint(ToUpper(s))
+}
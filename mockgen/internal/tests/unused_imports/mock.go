@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+
+// Package unused_imports is a generated GoMock package.
+package unused_imports
+
+import (
+	bytes "bytes"
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockWithUnusedImports is a mock of WithUnusedImports interface
+type MockWithUnusedImports struct {
+	ctrl     gomock.ControllerInterface
+	recorder *MockWithUnusedImportsMockRecorder
+}
+
+// MockWithUnusedImportsMockRecorder is the mock recorder for MockWithUnusedImports
+type MockWithUnusedImportsMockRecorder struct {
+	mock *MockWithUnusedImports
+}
+
+// Verify that the mock satisfies the interface at compile time.
+var _ WithUnusedImports = (*MockWithUnusedImports)(nil)
+
+// NewMockWithUnusedImports creates a new mock instance
+func NewMockWithUnusedImports(ctrl gomock.ControllerInterface) *MockWithUnusedImports {
+	mock := &MockWithUnusedImports{ctrl: ctrl}
+	mock.recorder = &MockWithUnusedImportsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockWithUnusedImports) EXPECT() *MockWithUnusedImportsMockRecorder {
+	return m.recorder
+}
+
+// Buffer mocks base method
+func (m *MockWithUnusedImports) Buffer() *bytes.Buffer {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Buffer")
+	ret0, _ := ret[0].(*bytes.Buffer)
+	return ret0
+}
+
+// Buffer indicates an expected call of Buffer
+func (mr *MockWithUnusedImportsMockRecorder) Buffer() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Buffer", reflect.TypeOf((*MockWithUnusedImports)(nil).Buffer))
+}
+
+// Context mocks base method
+func (m *MockWithUnusedImports) Context() context.Context {
+	m.ctrl.TestHelper().Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context
+func (mr *MockWithUnusedImportsMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.TestHelper().Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockWithUnusedImports)(nil).Context))
+}
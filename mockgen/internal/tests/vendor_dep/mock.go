@@ -12,7 +12,7 @@ import (
 
 // MockVendorsDep is a mock of VendorsDep interface
 type MockVendorsDep struct {
-	ctrl     *gomock.Controller
+	ctrl     gomock.ControllerInterface
 	recorder *MockVendorsDepMockRecorder
 }
 
@@ -21,8 +21,11 @@ type MockVendorsDepMockRecorder struct {
 	mock *MockVendorsDep
 }
 
+// Verify that the mock satisfies the interface at compile time.
+var _ VendorsDep = (*MockVendorsDep)(nil)
+
 // NewMockVendorsDep creates a new mock instance
-func NewMockVendorsDep(ctrl *gomock.Controller) *MockVendorsDep {
+func NewMockVendorsDep(ctrl gomock.ControllerInterface) *MockVendorsDep {
 	mock := &MockVendorsDep{ctrl: ctrl}
 	mock.recorder = &MockVendorsDepMockRecorder{mock}
 	return mock
@@ -35,7 +38,7 @@ func (m *MockVendorsDep) EXPECT() *MockVendorsDepMockRecorder {
 
 // Foo mocks base method
 func (m *MockVendorsDep) Foo() present.Elem {
-	m.ctrl.T.Helper()
+	m.ctrl.TestHelper().Helper()
 	ret := m.ctrl.Call(m, "Foo")
 	ret0, _ := ret[0].(present.Elem)
 	return ret0
@@ -43,6 +46,6 @@ func (m *MockVendorsDep) Foo() present.Elem {
 
 // Foo indicates an expected call of Foo
 func (mr *MockVendorsDepMockRecorder) Foo() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
+	mr.mock.ctrl.TestHelper().Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Foo", reflect.TypeOf((*MockVendorsDep)(nil).Foo))
 }
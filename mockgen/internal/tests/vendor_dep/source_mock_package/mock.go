@@ -12,7 +12,7 @@ import (
 
 // MockVendorsDep is a mock of VendorsDep interface
 type MockVendorsDep struct {
-	ctrl     *gomock.Controller
+	ctrl     gomock.ControllerInterface
 	recorder *MockVendorsDepMockRecorder
 }
 
@@ -22,7 +22,7 @@ type MockVendorsDepMockRecorder struct {
 }
 
 // NewMockVendorsDep creates a new mock instance
-func NewMockVendorsDep(ctrl *gomock.Controller) *MockVendorsDep {
+func NewMockVendorsDep(ctrl gomock.ControllerInterface) *MockVendorsDep {
 	mock := &MockVendorsDep{ctrl: ctrl}
 	mock.recorder = &MockVendorsDepMockRecorder{mock}
 	return mock
@@ -35,7 +35,7 @@ func (m *MockVendorsDep) EXPECT() *MockVendorsDepMockRecorder {
 
 // Foo mocks base method
 func (m *MockVendorsDep) Foo() present.Elem {
-	m.ctrl.T.Helper()
+	m.ctrl.TestHelper().Helper()
 	ret := m.ctrl.Call(m, "Foo")
 	ret0, _ := ret[0].(present.Elem)
 	return ret0
@@ -43,6 +43,6 @@ func (m *MockVendorsDep) Foo() present.Elem {
 
 // Foo indicates an expected call of Foo
 func (mr *MockVendorsDepMockRecorder) Foo() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
+	mr.mock.ctrl.TestHelper().Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Foo", reflect.TypeOf((*MockVendorsDep)(nil).Foo))
 }
@@ -11,7 +11,7 @@ import (
 
 // MockElem is a mock of Elem interface
 type MockElem struct {
-	ctrl     *gomock.Controller
+	ctrl     gomock.ControllerInterface
 	recorder *MockElemMockRecorder
 }
 
@@ -21,7 +21,7 @@ type MockElemMockRecorder struct {
 }
 
 // NewMockElem creates a new mock instance
-func NewMockElem(ctrl *gomock.Controller) *MockElem {
+func NewMockElem(ctrl gomock.ControllerInterface) *MockElem {
 	mock := &MockElem{ctrl: ctrl}
 	mock.recorder = &MockElemMockRecorder{mock}
 	return mock
@@ -34,7 +34,7 @@ func (m *MockElem) EXPECT() *MockElemMockRecorder {
 
 // TemplateName mocks base method
 func (m *MockElem) TemplateName() string {
-	m.ctrl.T.Helper()
+	m.ctrl.TestHelper().Helper()
 	ret := m.ctrl.Call(m, "TemplateName")
 	ret0, _ := ret[0].(string)
 	return ret0
@@ -42,6 +42,6 @@ func (m *MockElem) TemplateName() string {
 
 // TemplateName indicates an expected call of TemplateName
 func (mr *MockElemMockRecorder) TemplateName() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
+	mr.mock.ctrl.TestHelper().Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateName", reflect.TypeOf((*MockElem)(nil).TemplateName))
 }
@@ -15,9 +15,6 @@
 // MockGen generates mock implementations of Go interfaces.
 package main
 
-// TODO: This does not support recursive embedded interfaces.
-// TODO: This does not support embedding package-local interfaces in a separate file.
-
 import (
 	"bytes"
 	"encoding/json"
@@ -36,6 +33,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"text/template"
 	"unicode"
 
 	"github.com/golang/mock/mockgen/model"
@@ -47,12 +45,20 @@ const (
 
 var (
 	source          = flag.String("source", "", "(source mode) Input Go source file; enables source mode.")
-	destination     = flag.String("destination", "", "Output file; defaults to stdout.")
+	destination     = flag.String("destination", "", "Output file; defaults to stdout. May be a template such as 'mocks/{{.Package}}/{{.Interface}}_mock.go', in which case each interface is written to the file its expansion names; available variables are .Package (the source package name) and .Interface (the interface name). If it is a directory, such as 'mocks/', the file in it is named mock_<package>.go.")
 	mockNames       = flag.String("mock_names", "", "Comma-separated interfaceName=mockName pairs of explicit mock names to use. Mock names default to 'Mock'+ interfaceName suffix.")
 	packageOut      = flag.String("package", "", "Package of the generated code; defaults to the package of the input with a 'mock_' prefix.")
 	selfPackage     = flag.String("self_package", "", "The full package import path for the generated code. The purpose of this flag is to prevent import cycles in the generated code by trying to include its own package. This can happen if the mock's package is set to one of its inputs (usually the main one) and the output is stdio so mockgen cannot detect the final output package. Setting this flag will then tell mockgen which import to exclude.")
+	internalMock    = flag.Bool("internal", false, "Generate the mocks into the package of the input rather than a separate one, so that they can refer to its unexported types. This sets the package of the generated code and the import path excluded by -self_package to those of the input.")
 	writePkgComment = flag.Bool("write_package_comment", true, "Writes package documentation comment (godoc) if true.")
 	copyrightFile   = flag.String("copyright_file", "", "Copyright file used to add copyright header")
+	useAny          = flag.Bool("use_any", false, "Spell the empty interface 'any' instead of 'interface{}' throughout the generated code, however it is spelled in the input. The generated code then requires Go 1.18 or later.")
+	buildTag        = flag.String("build_tag", "", "Build constraint, such as 'mocks', that the generated code is compiled under. In source mode, it is combined with the build constraint of the source file, which otherwise applies alone; by default the generated code is always compiled.")
+	ctrlAccessor    = flag.Bool("ctrl_accessor", false, "Generate a Ctrl method on each mock that returns the *gomock.Controller it was created with.")
+	jsonStubs       = flag.Bool("json_stubs", false, "Generate a LoadFromJSON method on each mock that sets up its methods to return the values in a JSON fixture file.")
+	embedOrigins    = flag.Bool("embed_origins", false, "(source mode) Annotate each mock method of a method from an embedded interface with a '// from <EmbeddedInterface>' comment.")
+	adapter         = flag.String("adapter", "", "Comma-separated interfaceName=otherInterfaceName pairs. For each pair, an adapter type is generated that wraps interfaceName and implements otherInterfaceName by delegating to it; both must be parsed interfaces, and every method of otherInterfaceName must be a method of interfaceName with the same signature.")
+	alsoImplement   = flag.String("also_implement", "", "Comma-separated interfaceName=otherInterfaceName pairs. The mock of interfaceName also mocks the methods of otherInterfaceName, which must be one of the parsed interfaces.")
 
 	debugParser = flag.Bool("debug_parser", false, "Print out parser results only.")
 	version     = flag.Bool("version", false, "Print version.")
@@ -99,45 +105,35 @@ func main() {
 		return
 	}
 
-	dst := os.Stdout
-	if len(*destination) > 0 {
-		if err := os.MkdirAll(filepath.Dir(*destination), os.ModePerm); err != nil {
-			log.Fatalf("Unable to create directory: %v", err)
+	if isDestinationTemplate(*destination) {
+		if *adapter != "" {
+			log.Fatal("-adapter cannot be used with a destination template")
+		}
+		var extras map[string][]string
+		if *alsoImplement != "" {
+			extras = parseAlsoImplement(*alsoImplement)
 		}
-		f, err := os.Create(*destination)
+		outputs, err := splitByDestination(pkg, *destination, extras)
 		if err != nil {
-			log.Fatalf("Failed opening destination file: %v", err)
+			log.Fatalf("Invalid destination: %v", err)
 		}
-		defer f.Close()
-		dst = f
-	}
-
-	outputPackageName := *packageOut
-	if outputPackageName == "" {
-		// pkg.Name in reflect mode is the base name of the import path,
-		// which might have characters that are illegal to have in package names.
-		outputPackageName = "mock_" + sanitize(pkg.Name)
-	}
-
-	// outputPackagePath represents the fully qualified name of the package of
-	// the generated code. Its purposes are to prevent the module from importing
-	// itself and to prevent qualifying type names that come from its own
-	// package (i.e. if there is a type called X then we want to print "X" not
-	// "package.X" since "package" is this package). This can happen if the mock
-	// is output into an already existing package.
-	outputPackagePath := *selfPackage
-	if len(outputPackagePath) == 0 && len(*destination) > 0 {
-		dst, _ := filepath.Abs(filepath.Dir(*destination))
-		for _, prefix := range build.Default.SrcDirs() {
-			if strings.HasPrefix(dst, prefix) {
-				if rel, err := filepath.Rel(prefix, dst); err == nil {
-					outputPackagePath = rel
-					break
-				}
+		for _, out := range outputs {
+			g := newGenerator(packageName)
+			// Extra interfaces were already merged in by splitByDestination.
+			g.alsoImplement = nil
+			if err := writeMock(g, out.pkg, out.path); err != nil {
+				log.Fatal(err)
 			}
 		}
+		return
 	}
+	if err := writeMock(newGenerator(packageName), pkg, *destination); err != nil {
+		log.Fatal(err)
+	}
+}
 
+// newGenerator returns a generator configured from the command line flags.
+func newGenerator(packageName string) *generator {
 	g := new(generator)
 	if *source != "" {
 		g.filename = *source
@@ -149,6 +145,17 @@ func main() {
 	if *mockNames != "" {
 		g.mockNames = parseMockNames(*mockNames)
 	}
+	if *alsoImplement != "" {
+		g.alsoImplement = parseAlsoImplement(*alsoImplement)
+	}
+	if *adapter != "" {
+		g.adapters = parseAdapters(*adapter)
+	}
+	g.buildTag = *buildTag
+	g.useAny = *useAny
+	g.ctrlAccessor = *ctrlAccessor
+	g.jsonStubs = *jsonStubs
+	g.embedOrigins = *embedOrigins
 	if *copyrightFile != "" {
 		header, err := ioutil.ReadFile(*copyrightFile)
 		if err != nil {
@@ -157,12 +164,148 @@ func main() {
 
 		g.copyrightHeader = string(header)
 	}
+	return g
+}
+
+// writeMock generates the mocks for the interfaces of pkg and writes them to
+// destination, or to stdout if destination is empty. If destination is a
+// directory, the mocks are written to a file in it named after pkg.
+func writeMock(g *generator, pkg *model.Package, destination string) error {
+	destination = destinationFile(destination, pkg.Name)
+	dst := io.Writer(os.Stdout)
+	if len(destination) > 0 {
+		if err := os.MkdirAll(filepath.Dir(destination), os.ModePerm); err != nil {
+			return fmt.Errorf("Unable to create directory: %v", err)
+		}
+		f, err := os.Create(destination)
+		if err != nil {
+			return fmt.Errorf("Failed opening destination file: %v", err)
+		}
+		defer f.Close()
+		dst = f
+	}
+
+	// A main package cannot be imported, so its mocks must be part of it,
+	// typically in a _test.go file.
+	isMain := pkg.Name == "main"
+
+	outputPackageName := *packageOut
+	if *internalMock {
+		if outputPackageName != "" && outputPackageName != pkg.Name {
+			return fmt.Errorf("-internal generates mocks into package %s, but -package is %s", pkg.Name, outputPackageName)
+		}
+		outputPackageName = pkg.Name
+	}
+	if outputPackageName == "" && isMain {
+		outputPackageName = "main"
+	} else if outputPackageName == "" {
+		// pkg.Name in reflect mode is the base name of the import path,
+		// which might have characters that are illegal to have in package names.
+		outputPackageName = "mock_" + sanitize(pkg.Name)
+	}
+
+	// outputPackagePath represents the fully qualified name of the package of
+	// the generated code. Its purposes are to prevent the module from importing
+	// itself and to prevent qualifying type names that come from its own
+	// package (i.e. if there is a type called X then we want to print "X" not
+	// "package.X" since "package" is this package). This can happen if the mock
+	// is output into an already existing package.
+	outputPackagePath := *selfPackage
+	if len(outputPackagePath) == 0 && (isMain || *internalMock) {
+		outputPackagePath = pkg.PkgPath
+	} else if len(outputPackagePath) == 0 && len(destination) > 0 {
+		dst, _ := filepath.Abs(filepath.Dir(destination))
+		outputPackagePath = packagePathOfDir(dst)
+	}
+	if err := checkInternalImports(outputPackagePath, pkg.Imports()); err != nil {
+		return fmt.Errorf("Invalid destination: %v", err)
+	}
+
 	if err := g.Generate(pkg, outputPackageName, outputPackagePath); err != nil {
-		log.Fatalf("Failed generating mock: %v", err)
+		return fmt.Errorf("Failed generating mock: %v", err)
 	}
 	if _, err := dst.Write(g.Output()); err != nil {
-		log.Fatalf("Failed writing to destination: %v", err)
+		return fmt.Errorf("Failed writing to destination: %v", err)
+	}
+	return nil
+}
+
+// destinationFile returns the file to write the mocks of package pkgName to
+// for the given destination. A destination that is an existing directory or
+// ends in a path separator names a directory, which is created if needed, and
+// the file in it is named mock_<package>.go.
+func destinationFile(destination, pkgName string) string {
+	if destination == "" {
+		return ""
+	}
+	isDir := os.IsPathSeparator(destination[len(destination)-1])
+	if fi, err := os.Stat(destination); err == nil && fi.IsDir() {
+		isDir = true
+	}
+	if !isDir {
+		return destination
+	}
+	return filepath.Join(destination, "mock_"+sanitize(pkgName)+".go")
+}
+
+// destinationVars holds the variables available to a -destination template.
+type destinationVars struct {
+	Package   string // name of the source package
+	Interface string // name of the mocked interface
+}
+
+// isDestinationTemplate reports whether destination is a template that
+// must be expanded once per interface rather than a plain file name.
+func isDestinationTemplate(destination string) bool {
+	return strings.Contains(destination, "{{")
+}
+
+// mockOutput is a file to write and the interfaces to mock in it.
+type mockOutput struct {
+	path string
+	pkg  *model.Package
+}
+
+// splitByDestination expands the destination template for every interface of
+// pkg and groups the interfaces by the resulting path. Interfaces named in
+// alsoImplement are merged in beforehand, since the interfaces they refer to
+// may end up in a different file. The outputs are sorted by path.
+func splitByDestination(pkg *model.Package, destination string, alsoImplement map[string][]string) ([]mockOutput, error) {
+	tmpl, err := template.New("destination").Option("missingkey=error").Parse(destination)
+	if err != nil {
+		return nil, err
 	}
+
+	merger := &generator{alsoImplement: alsoImplement}
+	byPath := make(map[string]*model.Package)
+	for _, intf := range pkg.Interfaces {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, destinationVars{Package: pkg.Name, Interface: intf.Name}); err != nil {
+			return nil, err
+		}
+		path := buf.String()
+		if path == "" {
+			return nil, fmt.Errorf("destination template %q is empty for interface %v", destination, intf.Name)
+		}
+
+		intf, err := merger.withExtraInterfaces(pkg, intf)
+		if err != nil {
+			return nil, err
+		}
+		out, ok := byPath[path]
+		if !ok {
+			out = &model.Package{Name: pkg.Name, PkgPath: pkg.PkgPath, DotImports: pkg.DotImports, BuildConstraint: pkg.BuildConstraint}
+			byPath[path] = out
+		}
+		out.Interfaces = append(out.Interfaces, intf)
+	}
+
+	outputs := make([]mockOutput, 0, len(byPath))
+	for path, p := range byPath {
+		outputs = append(outputs, mockOutput{path: path, pkg: p})
+	}
+	sort.Slice(outputs, func(i, j int) bool { return outputs[i].path < outputs[j].path })
+	return outputs, nil
 }
 
 func parseMockNames(names string) map[string]string {
@@ -177,6 +320,36 @@ func parseMockNames(names string) map[string]string {
 	return mocksMap
 }
 
+// An adapterPair names an interface to wrap and the interface that its
+// adapter implements.
+type adapterPair struct {
+	from, to string
+}
+
+func parseAdapters(spec string) []adapterPair {
+	var pairs []adapterPair
+	for _, kv := range strings.Split(spec, ",") {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			log.Fatalf("bad adapter spec: %v", kv)
+		}
+		pairs = append(pairs, adapterPair{parts[0], parts[1]})
+	}
+	return pairs
+}
+
+func parseAlsoImplement(spec string) map[string][]string {
+	extras := make(map[string][]string)
+	for _, kv := range strings.Split(spec, ",") {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			log.Fatalf("bad also_implement spec: %v", kv)
+		}
+		extras[parts[0]] = append(extras[parts[0]], parts[1])
+	}
+	return extras
+}
+
 func usage() {
 	_, _ = io.WriteString(os.Stderr, usageText)
 	flag.PrintDefaults()
@@ -202,12 +375,20 @@ Example:
 type generator struct {
 	buf                       bytes.Buffer
 	indent                    string
-	mockNames                 map[string]string // may be empty
-	filename                  string            // may be empty
-	srcPackage, srcInterfaces string            // may be empty
+	mockNames                 map[string]string   // may be empty
+	alsoImplement             map[string][]string // may be empty
+	adapters                  []adapterPair       // may be empty
+	filename                  string              // may be empty
+	srcPackage, srcInterfaces string              // may be empty
 	copyrightHeader           string
-
-	packageMap map[string]string // map from import path to package name
+	buildTag                  string // may be empty
+	useAny                    bool
+	ctrlAccessor              bool
+	jsonStubs                 bool
+	embedOrigins              bool
+
+	packageMap     map[string]string // map from import path to package name
+	interfaceTypes map[string]string // map from interface name to its type in the generated code, if it can be referred to
 }
 
 func (g *generator) p(format string, args ...interface{}) {
@@ -260,6 +441,38 @@ func (g *generator) Generate(pkg *model.Package, outputPkgName string, outputPac
 		outputPackagePath = ""
 	}
 
+	var expr buildExpr
+	if g.buildTag != "" {
+		var err error
+		if expr, err = parseGoBuild(g.buildTag); err != nil {
+			return fmt.Errorf("invalid build tag %q: %v", g.buildTag, err)
+		}
+	}
+	if pkg.BuildConstraint != "" {
+		// The mocks only compile where the source file does.
+		src, err := parseGoBuild(pkg.BuildConstraint)
+		if err != nil {
+			return fmt.Errorf("invalid build constraint %q: %v", pkg.BuildConstraint, err)
+		}
+		if expr != nil {
+			src = andExpr{src, expr}
+		}
+		expr = src
+	}
+	if expr != nil {
+		// The constraint must precede the package clause and be followed by a
+		// blank line. The +build line is for Go versions before 1.17.
+		g.p("//go:build %v", expr)
+		plusBuild, err := plusBuildLines(expr)
+		if err != nil {
+			return fmt.Errorf("invalid build constraint %q: %v", expr, err)
+		}
+		for _, line := range plusBuild {
+			g.p("%v", line)
+		}
+		g.p("")
+	}
+
 	if g.copyrightHeader != "" {
 		lines := strings.Split(g.copyrightHeader, "\n")
 		for _, line := range lines {
@@ -276,10 +489,21 @@ func (g *generator) Generate(pkg *model.Package, outputPkgName string, outputPac
 	}
 	g.p("")
 
+	spellEmptyInterface(pkg, g.emptyInterface())
+
 	// Get all required imports, and generate unique names for them all.
 	im := pkg.Imports()
 	im[gomockImportPath] = true
 
+	srcPackagePath := pkg.PkgPath
+	if g.filename == "" {
+		srcPackagePath = g.srcPackage
+	}
+	// Only the import paths tell whether the mocks are in the package of the
+	// interfaces; a matching package name may be that of another directory.
+	samePackage := outputPackagePath != "" && outputPackagePath == srcPackagePath
+	assertable := g.assertableInterfaces(pkg, srcPackagePath, samePackage, im[srcPackagePath])
+
 	// Only import reflect if it's used. We only use reflect in mocked methods
 	// so only import if any of the mocked interfaces have methods.
 	for _, intf := range pkg.Interfaces {
@@ -327,7 +551,8 @@ func (g *generator) Generate(pkg *model.Package, outputPkgName string, outputPac
 		localNames[pkgName] = true
 	}
 
-	if *writePkgComment {
+	// The documentation of a main package is that of the command.
+	if *writePkgComment && outputPkgName != "main" {
 		g.p("// Package %v is a generated GoMock package.", outputPkgName)
 	}
 	g.p("package %v", outputPkgName)
@@ -346,12 +571,282 @@ func (g *generator) Generate(pkg *model.Package, outputPkgName string, outputPac
 	g.out()
 	g.p(")")
 
+	g.interfaceTypes = make(map[string]string, len(assertable))
+	for _, intf := range assertable {
+		nt := &model.NamedType{Package: srcPackagePath, Type: intf.Name}
+		if samePackage {
+			nt.Package = ""
+		}
+		g.interfaceTypes[intf.Name] = nt.String(g.packageMap, outputPackagePath)
+	}
+
 	for _, intf := range pkg.Interfaces {
+		intf, err := g.withExtraInterfaces(pkg, intf)
+		if err != nil {
+			return err
+		}
 		if err := g.GenerateMockInterface(intf, outputPackagePath); err != nil {
 			return err
 		}
 	}
 
+	for _, pair := range g.adapters {
+		if err := g.GenerateAdapter(pkg, pair, outputPackagePath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// emptyInterface returns the spelling of the empty interface type in the
+// generated code, as chosen by -use_any.
+func (g *generator) emptyInterface() string {
+	if g.useAny {
+		return "any"
+	}
+	return "interface{}"
+}
+
+// spellEmptyInterface rewrites the empty interface types in the method
+// signatures of pkg, whether spelled any or interface{} in the input, to
+// spelling, so that the generated code spells them consistently.
+func spellEmptyInterface(pkg *model.Package, spelling string) {
+	var fix func(t model.Type) model.Type
+	fixParams := func(params []*model.Parameter) {
+		for _, param := range params {
+			param.Type = fix(param.Type)
+		}
+	}
+	fix = func(t model.Type) model.Type {
+		switch t := t.(type) {
+		case model.PredeclaredType:
+			if t == "any" || t == "interface{}" {
+				return model.PredeclaredType(spelling)
+			}
+		case *model.ArrayType:
+			t.Type = fix(t.Type)
+		case *model.ChanType:
+			t.Type = fix(t.Type)
+		case *model.FuncType:
+			fixParams(t.In)
+			fixParams(t.Out)
+			if t.Variadic != nil {
+				t.Variadic.Type = fix(t.Variadic.Type)
+			}
+		case *model.MapType:
+			t.Key = fix(t.Key)
+			t.Value = fix(t.Value)
+		case *model.NamedType:
+			if t.TypeParams != nil {
+				for i, arg := range t.TypeParams.TypeParameters {
+					t.TypeParams.TypeParameters[i] = fix(arg)
+				}
+			}
+		case *model.PointerType:
+			t.Type = fix(t.Type)
+		}
+		return t
+	}
+	for _, intf := range pkg.Interfaces {
+		for _, m := range intf.Methods {
+			fixParams(m.In)
+			fixParams(m.Out)
+			if m.Variadic != nil {
+				m.Variadic.Type = fix(m.Variadic.Type)
+			}
+		}
+	}
+}
+
+// assertableInterfaces returns the interfaces of pkg that the generated code
+// can refer to, so that their mocks can be checked to satisfy them at compile
+// time. Interfaces in another package can only be referred to if the mocks
+// already import that package, and the interface and all its methods are
+// exported. The package is never imported just for the check, since that
+// could introduce an import cycle with tests of that package.
+func (g *generator) assertableInterfaces(pkg *model.Package, srcPackagePath string, samePackage, imported bool) []*model.Interface {
+	if samePackage {
+		return pkg.Interfaces
+	}
+	if srcPackagePath == "" || !imported || pkg.Name == "main" || strings.HasSuffix(g.filename, "_test.go") {
+		return nil
+	}
+
+	var assertable []*model.Interface
+	for _, intf := range pkg.Interfaces {
+		if !token.IsExported(intf.Name) {
+			continue
+		}
+		exported := true
+		for _, m := range intf.Methods {
+			if !token.IsExported(m.Name) {
+				exported = false
+				break
+			}
+		}
+		if exported {
+			assertable = append(assertable, intf)
+		}
+	}
+	return assertable
+}
+
+// withExtraInterfaces returns intf extended with the methods of the interfaces
+// that its mock should also implement, as requested by -also_implement.
+func (g *generator) withExtraInterfaces(pkg *model.Package, intf *model.Interface) (*model.Interface, error) {
+	extras := g.alsoImplement[intf.Name]
+	if len(extras) == 0 {
+		return intf, nil
+	}
+
+	combined := &model.Interface{Name: intf.Name, TypeParams: intf.TypeParams}
+	owners := make(map[string]string)
+	add := func(from *model.Interface) error {
+		for _, m := range from.Methods {
+			if owner, ok := owners[m.Name]; ok {
+				return fmt.Errorf("mock of %v cannot also implement %v: method %v is also declared by %v",
+					intf.Name, from.Name, m.Name, owner)
+			}
+			owners[m.Name] = from.Name
+			combined.Methods = append(combined.Methods, m)
+		}
+		return nil
+	}
+
+	if err := add(intf); err != nil {
+		return nil, err
+	}
+	for _, name := range extras {
+		var extra *model.Interface
+		for _, other := range pkg.Interfaces {
+			if other.Name == name {
+				extra = other
+				break
+			}
+		}
+		if extra == nil {
+			return nil, fmt.Errorf("mock of %v cannot also implement unknown interface %v", intf.Name, name)
+		}
+		if len(extra.TypeParams) > 0 {
+			return nil, fmt.Errorf("mock of %v cannot also implement generic interface %v", intf.Name, name)
+		}
+		if err := add(extra); err != nil {
+			return nil, err
+		}
+	}
+	return combined, nil
+}
+
+// GenerateAdapter generates a type that wraps a value of the interface
+// pair.from and implements the interface pair.to by delegating to it.
+func (g *generator) GenerateAdapter(pkg *model.Package, pair adapterPair, outputPackagePath string) error {
+	var from, to *model.Interface
+	for _, intf := range pkg.Interfaces {
+		switch intf.Name {
+		case pair.from:
+			from = intf
+		case pair.to:
+			to = intf
+		}
+	}
+	for _, name := range []string{pair.from, pair.to} {
+		if (name == pair.from && from == nil) || (name == pair.to && to == nil) {
+			return fmt.Errorf("cannot adapt %v to %v: unknown interface %v", pair.from, pair.to, name)
+		}
+		if _, ok := g.interfaceTypes[name]; !ok {
+			return fmt.Errorf("cannot adapt %v to %v: interface %v cannot be referred to from the generated code", pair.from, pair.to, name)
+		}
+	}
+	if len(from.TypeParams) > 0 || len(to.TypeParams) > 0 {
+		return fmt.Errorf("cannot adapt %v to %v: generic interfaces cannot be adapted", pair.from, pair.to)
+	}
+
+	signature := func(m *model.Method) string {
+		rets := make([]string, len(m.Out))
+		for i, p := range m.Out {
+			rets[i] = p.Type.String(g.packageMap, outputPackagePath)
+		}
+		sig := fmt.Sprintf("func(%v)", strings.Join(g.getArgTypes(m, outputPackagePath), ", "))
+		switch len(rets) {
+		case 0:
+			return sig
+		case 1:
+			return sig + " " + rets[0]
+		}
+		return fmt.Sprintf("%v (%v)", sig, strings.Join(rets, ", "))
+	}
+	fromMethods := make(map[string]*model.Method, len(from.Methods))
+	for _, m := range from.Methods {
+		fromMethods[m.Name] = m
+	}
+	for _, m := range to.Methods {
+		fm, ok := fromMethods[m.Name]
+		if !ok {
+			return fmt.Errorf("cannot adapt %v to %v: %v has no method %v", pair.from, pair.to, pair.from, m.Name)
+		}
+		if got, want := signature(fm), signature(m); got != want {
+			return fmt.Errorf("cannot adapt %v to %v: method %v is %v in %v, but %v in %v",
+				pair.from, pair.to, m.Name, got, pair.from, want, pair.to)
+		}
+	}
+
+	adapterType := pair.from + "As" + pair.to
+	fromType, toType := g.interfaceTypes[pair.from], g.interfaceTypes[pair.to]
+
+	g.p("")
+	g.p("// %v adapts a %v to the %v interface", adapterType, pair.from, pair.to)
+	g.p("type %v struct {", adapterType)
+	g.in()
+	g.p("adapted %v", fromType)
+	g.out()
+	g.p("}")
+	g.p("")
+	g.p("// Verify that the adapter satisfies the interface at compile time.")
+	g.p("var _ %v = (*%v)(nil)", toType, adapterType)
+	g.p("")
+	g.p("// New%v returns an adapter delegating to adapted", adapterType)
+	g.p("func New%v(adapted %v) *%v {", adapterType, fromType, adapterType)
+	g.in()
+	g.p("return &%v{adapted: adapted}", adapterType)
+	g.out()
+	g.p("}")
+
+	for _, m := range to.Methods {
+		argNames := g.getArgNames(m)
+		argString := makeArgString(argNames, g.getArgTypes(m, outputPackagePath))
+		rets := make([]string, len(m.Out))
+		for i, p := range m.Out {
+			rets[i] = p.Type.String(g.packageMap, outputPackagePath)
+		}
+		retString := strings.Join(rets, ", ")
+		if len(rets) > 1 {
+			retString = "(" + retString + ")"
+		}
+		if retString != "" {
+			retString = " " + retString
+		}
+
+		callArgs := strings.Join(argNames, ", ")
+		if m.Variadic != nil {
+			callArgs += "..."
+		}
+		var ret string
+		if len(m.Out) > 0 {
+			ret = "return "
+		}
+
+		ia := newIdentifierAllocator(argNames)
+		idRecv := ia.allocateIdentifier("a")
+
+		g.p("")
+		g.p("// %v delegates to the adapted %v", m.Name, pair.from)
+		g.p("func (%v *%v) %v(%v)%v {", idRecv, adapterType, m.Name, argString, retString)
+		g.in()
+		g.p("%v%v.adapted.%v(%v)", ret, idRecv, m.Name, callArgs)
+		g.out()
+		g.p("}")
+	}
 	return nil
 }
 
@@ -364,37 +859,64 @@ func (g *generator) mockName(typeName string) string {
 	return "Mock" + typeName
 }
 
+// typeParams returns the type parameter list of the mock of a generic
+// interface, such as "[K comparable, V any]", and the type arguments that
+// refer to the mock within its methods, such as "[K, V]". Both are empty if
+// intf is not generic.
+func (g *generator) typeParams(intf *model.Interface, pkgOverride string) (params, args string) {
+	if len(intf.TypeParams) == 0 {
+		return "", ""
+	}
+	names := make([]string, len(intf.TypeParams))
+	constraints := make([]string, len(intf.TypeParams))
+	for i, tp := range intf.TypeParams {
+		names[i] = tp.Name
+		constraints[i] = tp.Type.String(g.packageMap, pkgOverride)
+	}
+	return "[" + makeArgString(names, constraints) + "]", "[" + strings.Join(names, ", ") + "]"
+}
+
 func (g *generator) GenerateMockInterface(intf *model.Interface, outputPackagePath string) error {
 	mockType := g.mockName(intf.Name)
+	typeParams, typeArgs := g.typeParams(intf, outputPackagePath)
 
 	g.p("")
 	g.p("// %v is a mock of %v interface", mockType, intf.Name)
-	g.p("type %v struct {", mockType)
+	g.p("type %v%v struct {", mockType, typeParams)
 	g.in()
-	g.p("ctrl     *gomock.Controller")
-	g.p("recorder *%vMockRecorder", mockType)
+	g.p("ctrl     gomock.ControllerInterface")
+	g.p("recorder *%vMockRecorder%v", mockType, typeArgs)
 	g.out()
 	g.p("}")
 	g.p("")
 
 	g.p("// %vMockRecorder is the mock recorder for %v", mockType, mockType)
-	g.p("type %vMockRecorder struct {", mockType)
+	g.p("type %vMockRecorder%v struct {", mockType, typeParams)
 	g.in()
-	g.p("mock *%v", mockType)
+	g.p("mock *%v%v", mockType, typeArgs)
 	g.out()
 	g.p("}")
 	g.p("")
 
-	// TODO: Re-enable this if we can import the interface reliably.
-	// g.p("// Verify that the mock satisfies the interface at compile time.")
-	// g.p("var _ %v = (*%v)(nil)", typeName, mockType)
-	// g.p("")
+	// Mock methods have pointer receivers, so only the pointer type is
+	// guaranteed to satisfy the interface. A generic interface can only be
+	// referred to instantiated, so its check is made for any type arguments
+	// in a generic function.
+	if typeName, ok := g.interfaceTypes[intf.Name]; ok {
+		g.p("// Verify that the mock satisfies the interface at compile time.")
+		if typeParams == "" {
+			g.p("var _ %v = (*%v)(nil)", typeName, mockType)
+		} else {
+			g.p("func _%v() { var _ %v%v = (*%v%v)(nil) }", typeParams, typeName, typeArgs, mockType, typeArgs)
+		}
+		g.p("")
+	}
 
 	g.p("// New%v creates a new mock instance", mockType)
-	g.p("func New%v(ctrl *gomock.Controller) *%v {", mockType, mockType)
+	g.p("func New%v%v(ctrl gomock.ControllerInterface) *%v%v {", mockType, typeParams, mockType, typeArgs)
 	g.in()
-	g.p("mock := &%v{ctrl: ctrl}", mockType)
-	g.p("mock.recorder = &%vMockRecorder{mock}", mockType)
+	g.p("mock := &%v%v{ctrl: ctrl}", mockType, typeArgs)
+	g.p("mock.recorder = &%vMockRecorder%v{mock}", mockType, typeArgs)
 	g.p("return mock")
 	g.out()
 	g.p("}")
@@ -402,23 +924,47 @@ func (g *generator) GenerateMockInterface(intf *model.Interface, outputPackagePa
 
 	// XXX: possible name collision here if someone has EXPECT in their interface.
 	g.p("// EXPECT returns an object that allows the caller to indicate expected use")
-	g.p("func (m *%v) EXPECT() *%vMockRecorder {", mockType, mockType)
+	g.p("func (m *%v%v) EXPECT() *%vMockRecorder%v {", mockType, typeArgs, mockType, typeArgs)
 	g.in()
 	g.p("return m.recorder")
 	g.out()
 	g.p("}")
 
+	if g.ctrlAccessor {
+		g.p("")
+		g.p("// Ctrl returns the controller the mock was created with, or nil if it is")
+		g.p("// not a *gomock.Controller")
+		g.p("func (m *%v%v) Ctrl() *gomock.Controller {", mockType, typeArgs)
+		g.in()
+		g.p("ctrl, _ := m.ctrl.(*gomock.Controller)")
+		g.p("return ctrl")
+		g.out()
+		g.p("}")
+	}
+
+	if g.jsonStubs {
+		g.p("")
+		g.p("// LoadFromJSON sets up the mock to return the values in the JSON fixture at")
+		g.p("// path; see gomock.LoadReturnsFromJSON for its format")
+		g.p("func (m *%v%v) LoadFromJSON(path string) error {", mockType, typeArgs)
+		g.in()
+		g.p("return gomock.LoadReturnsFromJSON(m, m.recorder, path)")
+		g.out()
+		g.p("}")
+	}
+
 	g.GenerateMockMethods(mockType, intf, outputPackagePath)
 
 	return nil
 }
 
 func (g *generator) GenerateMockMethods(mockType string, intf *model.Interface, pkgOverride string) {
+	_, typeArgs := g.typeParams(intf, pkgOverride)
 	for _, m := range intf.Methods {
 		g.p("")
-		_ = g.GenerateMockMethod(mockType, m, pkgOverride)
+		_ = g.GenerateMockMethod(mockType+typeArgs, m, pkgOverride)
 		g.p("")
-		_ = g.GenerateMockRecorderMethod(mockType, m)
+		_ = g.GenerateMockRecorderMethod(mockType, typeArgs, m)
 	}
 }
 
@@ -435,7 +981,8 @@ func makeArgString(argNames, argTypes []string) string {
 	return strings.Join(args, ", ")
 }
 
-// GenerateMockMethod generates a mock method implementation.
+// GenerateMockMethod generates a mock method implementation. mockType includes
+// the type arguments of a generic mock.
 // If non-empty, pkgOverride is the package in which unqualified types reside.
 func (g *generator) GenerateMockMethod(mockType string, m *model.Method, pkgOverride string) error {
 	argNames := g.getArgNames(m)
@@ -446,21 +993,34 @@ func (g *generator) GenerateMockMethod(mockType string, m *model.Method, pkgOver
 	for i, p := range m.Out {
 		rets[i] = p.Type.String(g.packageMap, pkgOverride)
 	}
+	retNames := g.getRetNames(m)
 	retString := strings.Join(rets, ", ")
-	if len(rets) > 1 {
+	if len(retNames) > 0 {
+		retString = makeArgString(retNames, rets)
+	}
+	if len(rets) > 1 || len(retNames) > 0 {
 		retString = "(" + retString + ")"
 	}
 	if retString != "" {
 		retString = " " + retString
 	}
 
-	ia := newIdentifierAllocator(argNames)
+	ia := newIdentifierAllocator(append(argNames, retNames...))
 	idRecv := ia.allocateIdentifier("m")
 
 	g.p("// %v mocks base method", m.Name)
+	if g.embedOrigins && m.Origin != "" {
+		g.p("// from %v", m.Origin)
+	}
+	if m.Deprecated != "" {
+		g.p("//")
+		for _, line := range strings.Split(m.Deprecated, "\n") {
+			g.p("// %v", line)
+		}
+	}
 	g.p("func (%v *%v) %v(%v)%v {", idRecv, mockType, m.Name, argString, retString)
 	g.in()
-	g.p("%s.ctrl.T.Helper()", idRecv)
+	g.p("%s.ctrl.TestHelper().Helper()", idRecv)
 
 	var callArgs string
 	if m.Variadic == nil {
@@ -472,7 +1032,7 @@ func (g *generator) GenerateMockMethod(mockType string, m *model.Method, pkgOver
 		// but the variadic argument may be any type.
 		idVarArgs := ia.allocateIdentifier("varargs")
 		idVArg := ia.allocateIdentifier("a")
-		g.p("%s := []interface{}{%s}", idVarArgs, strings.Join(argNames[:len(argNames)-1], ", "))
+		g.p("%s := []%s{%s}", idVarArgs, g.emptyInterface(), strings.Join(argNames[:len(argNames)-1], ", "))
 		g.p("for _, %s := range %s {", idVArg, argNames[len(argNames)-1])
 		g.in()
 		g.p("%s = append(%s, %s)", idVarArgs, idVarArgs, idVArg)
@@ -489,12 +1049,12 @@ func (g *generator) GenerateMockMethod(mockType string, m *model.Method, pkgOver
 		// Go does not allow "naked" type assertions on nil values, so we use the two-value form here.
 		// The value of that is either (x.(T), true) or (Z, false), where Z is the zero value for T.
 		// Happily, this coincides with the semantics we want here.
-		retNames := make([]string, len(rets))
+		retVars := make([]string, len(rets))
 		for i, t := range rets {
-			retNames[i] = ia.allocateIdentifier(fmt.Sprintf("ret%d", i))
-			g.p("%s, _ := %s[%d].(%s)", retNames[i], idRet, i, t)
+			retVars[i] = ia.allocateIdentifier(fmt.Sprintf("ret%d", i))
+			g.p("%s, _ := %s[%d].(%s)", retVars[i], idRet, i, t)
 		}
-		g.p("return " + strings.Join(retNames, ", "))
+		g.p("return " + strings.Join(retVars, ", "))
 	}
 
 	g.out()
@@ -502,7 +1062,9 @@ func (g *generator) GenerateMockMethod(mockType string, m *model.Method, pkgOver
 	return nil
 }
 
-func (g *generator) GenerateMockRecorderMethod(mockType string, m *model.Method) error {
+// GenerateMockRecorderMethod generates a mock recorder method. typeArgs are the
+// type arguments of a generic mock, such as "[T]", or empty.
+func (g *generator) GenerateMockRecorderMethod(mockType, typeArgs string, m *model.Method) error {
 	argNames := g.getArgNames(m)
 
 	var argString string
@@ -512,23 +1074,23 @@ func (g *generator) GenerateMockRecorderMethod(mockType string, m *model.Method)
 		argString = strings.Join(argNames[:len(argNames)-1], ", ")
 	}
 	if argString != "" {
-		argString += " interface{}"
+		argString += " " + g.emptyInterface()
 	}
 
 	if m.Variadic != nil {
 		if argString != "" {
 			argString += ", "
 		}
-		argString += fmt.Sprintf("%s ...interface{}", argNames[len(argNames)-1])
+		argString += fmt.Sprintf("%s ...%s", argNames[len(argNames)-1], g.emptyInterface())
 	}
 
 	ia := newIdentifierAllocator(argNames)
 	idRecv := ia.allocateIdentifier("mr")
 
 	g.p("// %v indicates an expected call of %v", m.Name, m.Name)
-	g.p("func (%s *%vMockRecorder) %v(%v) *gomock.Call {", idRecv, mockType, m.Name, argString)
+	g.p("func (%s *%vMockRecorder%v) %v(%v) *gomock.Call {", idRecv, mockType, typeArgs, m.Name, argString)
 	g.in()
-	g.p("%s.mock.ctrl.T.Helper()", idRecv)
+	g.p("%s.mock.ctrl.TestHelper().Helper()", idRecv)
 
 	var callArgs string
 	if m.Variadic == nil {
@@ -542,14 +1104,15 @@ func (g *generator) GenerateMockRecorderMethod(mockType string, m *model.Method)
 		} else {
 			// Hard: create a temporary slice.
 			idVarArgs := ia.allocateIdentifier("varargs")
-			g.p("%s := append([]interface{}{%s}, %s...)",
+			g.p("%s := append([]%s{%s}, %s...)",
 				idVarArgs,
+				g.emptyInterface(),
 				strings.Join(argNames[:len(argNames)-1], ", "),
 				argNames[len(argNames)-1])
 			callArgs = ", " + idVarArgs + "..."
 		}
 	}
-	g.p(`return %s.mock.ctrl.RecordCallWithMethodType(%s.mock, "%s", reflect.TypeOf((*%s)(nil).%s)%s)`, idRecv, idRecv, m.Name, mockType, m.Name, callArgs)
+	g.p(`return %s.mock.ctrl.RecordCallWithMethodType(%s.mock, "%s", reflect.TypeOf((*%s%s)(nil).%s)%s)`, idRecv, idRecv, m.Name, mockType, typeArgs, m.Name, callArgs)
 
 	g.out()
 	g.p("}")
@@ -575,6 +1138,19 @@ func (g *generator) getArgNames(m *model.Method) []string {
 	return argNames
 }
 
+// getRetNames returns the names of the return values of m, or nil if they
+// are not named. Blank names are kept, since they are valid in a signature.
+func (g *generator) getRetNames(m *model.Method) []string {
+	if len(m.Out) == 0 || m.Out[0].Name == "" {
+		return nil
+	}
+	retNames := make([]string, len(m.Out))
+	for i, p := range m.Out {
+		retNames[i] = p.Name
+	}
+	return retNames
+}
+
 func (g *generator) getArgTypes(m *model.Method, pkgOverride string) []string {
 	argTypes := make([]string, len(m.In))
 	for i, p := range m.In {
@@ -616,6 +1192,89 @@ func (g *generator) Output() []byte {
 	return src
 }
 
+// packagePathOfDir returns the import path of the package in dir, which need
+// not exist yet. The path is derived from GOPATH if dir is inside it, or else
+// from the nearest enclosing go.mod file. It returns "" if neither applies.
+func packagePathOfDir(dir string) string {
+	for _, prefix := range build.Default.SrcDirs() {
+		if strings.HasPrefix(dir, prefix) {
+			if rel, err := filepath.Rel(prefix, dir); err == nil {
+				return filepath.ToSlash(rel)
+			}
+		}
+	}
+
+	for modDir := dir; ; {
+		if modPath := modulePath(filepath.Join(modDir, "go.mod")); modPath != "" {
+			rel, err := filepath.Rel(modDir, dir)
+			if err != nil {
+				return ""
+			}
+			return path.Join(modPath, filepath.ToSlash(rel))
+		}
+		parent := filepath.Dir(modDir)
+		if parent == modDir {
+			return ""
+		}
+		modDir = parent
+	}
+}
+
+// modulePath returns the module path declared in the given go.mod file, or ""
+// if the file cannot be read or has no module directive.
+func modulePath(goMod string) string {
+	b, err := ioutil.ReadFile(goMod)
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "module" {
+			if p, err := strconv.Unquote(fields[1]); err == nil {
+				return p
+			}
+			return fields[1]
+		}
+	}
+	return ""
+}
+
+// checkInternalImports returns an error if the package at outputPackagePath
+// is not allowed to import one of the given import paths because of Go's
+// internal package visibility rules. If outputPackagePath is unknown, no
+// check is done.
+func checkInternalImports(outputPackagePath string, imports map[string]bool) error {
+	if outputPackagePath == "" {
+		return nil
+	}
+	for pth := range imports {
+		if !canImport(outputPackagePath, pth) {
+			return fmt.Errorf("package %s cannot import internal package %s", outputPackagePath, pth)
+		}
+	}
+	return nil
+}
+
+// canImport reports whether the package at importer may import importPath
+// with respect to internal packages: an "internal" path element may only be
+// imported by code rooted at the parent of the internal directory.
+func canImport(importer, importPath string) bool {
+	var parent string
+	switch {
+	case strings.HasSuffix(importPath, "/internal"):
+		parent = strings.TrimSuffix(importPath, "/internal")
+	case strings.Contains(importPath, "/internal/"):
+		// The last internal element is the most restrictive one.
+		parent = importPath[:strings.LastIndex(importPath, "/internal/")]
+	case importPath == "internal", strings.HasPrefix(importPath, "internal/"):
+		// Only the standard library may import these.
+		return !strings.Contains(strings.SplitN(importer, "/", 2)[0], ".")
+	default:
+		return true
+	}
+	return importer == parent || strings.HasPrefix(importer, parent+"/")
+}
+
 func lookupPackageName(importPath string) (string, bool) {
 	var pkg struct {
 		Name string
@@ -2,6 +2,9 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strings"
@@ -192,12 +195,12 @@ func TestGenerateMockInterface_Helper(t *testing.T) {
 		HelperLine string
 		Methods    []*model.Method
 	}{
-		{Name: "mock", Identifier: "MockSomename", HelperLine: "m.ctrl.T.Helper()"},
-		{Name: "recorder", Identifier: "MockSomenameMockRecorder", HelperLine: "mr.mock.ctrl.T.Helper()"},
+		{Name: "mock", Identifier: "MockSomename", HelperLine: "m.ctrl.TestHelper().Helper()"},
+		{Name: "recorder", Identifier: "MockSomenameMockRecorder", HelperLine: "mr.mock.ctrl.TestHelper().Helper()"},
 		{
 			Name:       "mock identifier conflict",
 			Identifier: "MockSomename",
-			HelperLine: "m_2.ctrl.T.Helper()",
+			HelperLine: "m_2.ctrl.TestHelper().Helper()",
 			Methods: []*model.Method{
 				{
 					Name: "MethodA",
@@ -213,7 +216,7 @@ func TestGenerateMockInterface_Helper(t *testing.T) {
 		{
 			Name:       "recorder identifier conflict",
 			Identifier: "MockSomenameMockRecorder",
-			HelperLine: "mr_2.mock.ctrl.T.Helper()",
+			HelperLine: "mr_2.mock.ctrl.TestHelper().Helper()",
 			Methods: []*model.Method{
 				{
 					Name: "MethodA",
@@ -362,3 +365,418 @@ func Test_lookupPackageName(t *testing.T) {
 		})
 	}
 }
+
+func TestCanImport(t *testing.T) {
+	for _, tt := range []struct {
+		importer, importPath string
+		want                 bool
+	}{
+		{"example.com/a/b", "example.com/c", true},
+		{"example.com/a", "example.com/a/internal", true},
+		{"example.com/a/b", "example.com/a/internal/pkg", true},
+		{"example.com/a/internal/pkg/mock_pkg", "example.com/a/internal/pkg", true},
+		{"example.com/ab", "example.com/a/internal/pkg", false},
+		{"example.com/b", "example.com/a/internal", false},
+		{"example.com/a/b", "example.com/a/internal/x/internal/y", false},
+		{"example.com/a/internal/x/z", "example.com/a/internal/x/internal/y", true},
+		{"example.com/a", "internal/cpu", false},
+	} {
+		if got := canImport(tt.importer, tt.importPath); got != tt.want {
+			t.Errorf("canImport(%q, %q) = %v, want %v", tt.importer, tt.importPath, got, tt.want)
+		}
+	}
+}
+
+func TestPackagePathOfDir(t *testing.T) {
+	dir, err := filepath.Abs("internal/tests/internal_pkg/subdir/mock_pkg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "github.com/golang/mock/mockgen/internal/tests/internal_pkg/subdir/mock_pkg"
+	if got := packagePathOfDir(dir); got != want {
+		t.Errorf("packagePathOfDir(%q) = %q, want %q", dir, got, want)
+	}
+}
+
+func TestWithExtraInterfaces(t *testing.T) {
+	store := &model.Interface{Name: "Store", Methods: []*model.Method{{Name: "Get"}, {Name: "Close"}}}
+	closer := &model.Interface{Name: "Closer", Methods: []*model.Method{{Name: "Close"}}}
+	flusher := &model.Interface{Name: "Flusher", Methods: []*model.Method{{Name: "Flush"}}}
+	pkg := &model.Package{Interfaces: []*model.Interface{store, closer, flusher}}
+
+	g := generator{alsoImplement: map[string][]string{"Store": {"Flusher"}}}
+	intf, err := g.withExtraInterfaces(pkg, store)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := len(intf.Methods); got != 3 {
+		t.Errorf("expected 3 methods, got %d", got)
+	}
+	if got := len(store.Methods); got != 2 {
+		t.Errorf("original interface was modified: expected 2 methods, got %d", got)
+	}
+
+	g = generator{alsoImplement: map[string][]string{"Store": {"Closer"}}}
+	if _, err := g.withExtraInterfaces(pkg, store); err == nil || !strings.Contains(err.Error(), "method Close is also declared by Store") {
+		t.Errorf("expected method collision error, got %v", err)
+	}
+
+	g = generator{alsoImplement: map[string][]string{"Store": {"Missing"}}}
+	if _, err := g.withExtraInterfaces(pkg, store); err == nil || !strings.Contains(err.Error(), "unknown interface Missing") {
+		t.Errorf("expected unknown interface error, got %v", err)
+	}
+}
+
+func TestAssertableInterfaces(t *testing.T) {
+	pkg := &model.Package{
+		Name: "src",
+		Interfaces: []*model.Interface{
+			{Name: "Exported", Methods: []*model.Method{{Name: "Foo"}}},
+			{Name: "unexported", Methods: []*model.Method{{Name: "Foo"}}},
+			{Name: "UnexportedMethod", Methods: []*model.Method{{Name: "foo"}}},
+		},
+	}
+	names := func(intfs []*model.Interface) []string {
+		var names []string
+		for _, intf := range intfs {
+			names = append(names, intf.Name)
+		}
+		return names
+	}
+
+	g := generator{}
+	if got, want := names(g.assertableInterfaces(pkg, "example.com/src", true, false)), []string{"Exported", "unexported", "UnexportedMethod"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("same package: got %v, want %v", got, want)
+	}
+	if got, want := names(g.assertableInterfaces(pkg, "example.com/src", false, true)), []string{"Exported"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("other package: got %v, want %v", got, want)
+	}
+	if got := g.assertableInterfaces(pkg, "example.com/src", false, false); len(got) != 0 {
+		t.Errorf("source package not imported: got %v, want none", names(got))
+	}
+
+	g = generator{filename: "src_test.go"}
+	if got := g.assertableInterfaces(pkg, "example.com/src", false, true); len(got) != 0 {
+		t.Errorf("test file source: got %v, want none", names(got))
+	}
+}
+
+func TestGenerateMockInterface_ControllerInterface(t *testing.T) {
+	g := generator{}
+	if err := g.GenerateMockInterface(&model.Interface{
+		Name:    "Somename",
+		Methods: []*model.Method{{Name: "MethodA"}},
+	}, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"ctrl     gomock.ControllerInterface\n",
+		"func NewMockSomename(ctrl gomock.ControllerInterface) *MockSomename {\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated mock does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestTemplatedDestination(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mockgen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	pkg := &model.Package{
+		Name: "store",
+		Interfaces: []*model.Interface{
+			{Name: "Reader", Methods: []*model.Method{{Name: "Read"}}},
+			{Name: "Writer", Methods: []*model.Method{{Name: "Write"}}},
+		},
+	}
+	destination := filepath.Join(dir, "mocks", "{{.Package}}", "{{.Interface}}_mock.go")
+	outputs, err := splitByDestination(pkg, destination, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(outputs) != 2 {
+		t.Fatalf("expected 2 outputs, got %d", len(outputs))
+	}
+
+	for _, name := range []string{"Reader", "Writer"} {
+		path := filepath.Join(dir, "mocks", "store", name+"_mock.go")
+		var found bool
+		for _, out := range outputs {
+			if out.path != path {
+				continue
+			}
+			found = true
+			if err := writeMock(new(generator), out.pkg, out.path); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		if !found {
+			t.Fatalf("no output for %v at %v", name, path)
+		}
+
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !strings.Contains(string(b), "type Mock"+name+" struct") {
+			t.Errorf("%v does not contain Mock%v:\n%s", path, name, b)
+		}
+	}
+}
+
+func TestTemplatedDestination_Invalid(t *testing.T) {
+	pkg := &model.Package{Name: "store", Interfaces: []*model.Interface{{Name: "Reader"}}}
+	if _, err := splitByDestination(pkg, "{{.Missing}}.go", nil); err == nil {
+		t.Error("expected error for unknown template variable")
+	}
+	if _, err := splitByDestination(pkg, "{{.Package", nil); err == nil {
+		t.Error("expected error for malformed template")
+	}
+}
+
+func TestDestinationDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mockgen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	pkg := &model.Package{Name: "store", Interfaces: []*model.Interface{{Name: "Reader", Methods: []*model.Method{{Name: "Read"}}}}}
+	for _, destination := range []string{
+		dir,                                 // existing directory
+		filepath.Join(dir, "mocks") + "/",   // directory to create
+		filepath.Join(dir, "mocks", "x.go"), // plain file
+	} {
+		if err := writeMock(new(generator), pkg, destination); err != nil {
+			t.Fatalf("Unexpected error for %v: %v", destination, err)
+		}
+	}
+
+	for _, path := range []string{
+		filepath.Join(dir, "mock_store.go"),
+		filepath.Join(dir, "mocks", "mock_store.go"),
+		filepath.Join(dir, "mocks", "x.go"),
+	} {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Expected mock at %v: %v", path, err)
+		}
+		if !strings.Contains(string(b), "type MockReader struct") {
+			t.Errorf("%v does not contain MockReader:\n%s", path, b)
+		}
+	}
+}
+
+func TestWriteMock_Internal(t *testing.T) {
+	*internalMock = true
+	defer func() { *internalMock = false }()
+
+	dir, err := ioutil.TempDir("", "mockgen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	entry := &model.NamedType{Package: "example.com/store", Type: "entry"}
+	pkg := &model.Package{
+		Name:    "store",
+		PkgPath: "example.com/store",
+		Interfaces: []*model.Interface{{Name: "Reader", Methods: []*model.Method{{
+			Name: "Read",
+			Out:  []*model.Parameter{{Type: &model.PointerType{Type: entry}}},
+		}}}},
+	}
+	destination := filepath.Join(dir, "mock_test.go")
+	if err := writeMock(&generator{srcPackage: pkg.PkgPath}, pkg, destination); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	b, err := ioutil.ReadFile(destination)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(b)
+	for _, want := range []string{"package store\n", "func (m *MockReader) Read() *entry {", "var _ Reader = (*MockReader)(nil)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, `"example.com/store"`) {
+		t.Errorf("generated code imports its own package:\n%s", out)
+	}
+
+	*packageOut = "mock_store"
+	defer func() { *packageOut = "" }()
+	if err := writeMock(new(generator), pkg, destination); err == nil {
+		t.Error("expected error for -package conflicting with -internal")
+	}
+}
+
+func TestGenerate_BuildTag(t *testing.T) {
+	pkg := &model.Package{
+		Name:       "store",
+		Interfaces: []*model.Interface{{Name: "Reader", Methods: []*model.Method{{Name: "Read"}}}},
+	}
+	g := generator{buildTag: "mocks", filename: "store.go"}
+	if err := g.Generate(pkg, "mock_store", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	out := string(g.Output())
+	want := "//go:build mocks\n// +build mocks\n\n// Code generated by MockGen. DO NOT EDIT.\n"
+	if !strings.HasPrefix(out, want) {
+		t.Errorf("generated code does not start with %q:\n%s", want, out)
+	}
+
+	g = generator{buildTag: "mocks &&", filename: "store.go"}
+	if err := g.Generate(pkg, "mock_store", ""); err == nil {
+		t.Error("expected error for invalid build tag")
+	}
+}
+
+func TestGenerate_SamePackageName(t *testing.T) {
+	pkg := &model.Package{
+		Name:       "foo",
+		PkgPath:    "example.com/foo",
+		Interfaces: []*model.Interface{{Name: "Getter", Methods: []*model.Method{{Name: "Get"}}}},
+	}
+	for _, tc := range []struct {
+		outputPackagePath string
+		wantAssertion     bool
+	}{
+		{"example.com/foo", true},
+		{"example.com/foo/mocks", false},
+		{"", false},
+	} {
+		g := generator{filename: "foo.go"}
+		if err := g.Generate(pkg, "foo", tc.outputPackagePath); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		out := string(g.Output())
+		if got := strings.Contains(out, "var _ Getter = (*MockGetter)(nil)"); got != tc.wantAssertion {
+			t.Errorf("output package %q: generated assertion = %v, want %v:\n%s", tc.outputPackagePath, got, tc.wantAssertion, out)
+		}
+	}
+}
+
+func TestGenerate_UseAny(t *testing.T) {
+	newPkg := func() *model.Package {
+		return &model.Package{
+			Name: "cache",
+			Interfaces: []*model.Interface{{Name: "Cache", Methods: []*model.Method{{
+				Name:     "Put",
+				In:       []*model.Parameter{{Name: "v", Type: model.PredeclaredType("any")}},
+				Variadic: &model.Parameter{Name: "vs", Type: model.PredeclaredType("interface{}")},
+			}}}},
+		}
+	}
+
+	for _, tc := range []struct {
+		useAny      bool
+		want, avoid string
+	}{
+		{false, "interface{}", "any"},
+		{true, "any", "interface{}"},
+	} {
+		g := generator{useAny: tc.useAny, filename: "cache.go"}
+		if err := g.Generate(newPkg(), "mock_cache", ""); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		out := string(g.Output())
+		if !strings.Contains(out, "Put(v "+tc.want+", vs ..."+tc.want+")") {
+			t.Errorf("useAny=%v: mock method not spelled with %s:\n%s", tc.useAny, tc.want, out)
+		}
+		if strings.Contains(out, " "+tc.avoid+",") || strings.Contains(out, "..."+tc.avoid) || strings.Contains(out, "[]"+tc.avoid) {
+			t.Errorf("useAny=%v: generated code spells %s:\n%s", tc.useAny, tc.avoid, out)
+		}
+	}
+}
+
+func TestGenerate_CtrlAccessor(t *testing.T) {
+	pkg := &model.Package{
+		Name:       "store",
+		Interfaces: []*model.Interface{{Name: "Reader", Methods: []*model.Method{{Name: "Read"}}}},
+	}
+	want := "func (m *MockReader) Ctrl() *gomock.Controller {\n\tctrl, _ := m.ctrl.(*gomock.Controller)\n\treturn ctrl\n}\n"
+
+	g := generator{filename: "store.go"}
+	if err := g.Generate(pkg, "mock_store", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out := string(g.Output()); strings.Contains(out, "Ctrl()") {
+		t.Errorf("generated code has a Ctrl method without -ctrl_accessor:\n%s", out)
+	}
+
+	g = generator{ctrlAccessor: true, filename: "store.go"}
+	if err := g.Generate(pkg, "mock_store", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out := string(g.Output()); !strings.Contains(out, want) {
+		t.Errorf("generated code does not contain %q:\n%s", want, out)
+	}
+}
+
+func TestGenerate_JSONStubs(t *testing.T) {
+	pkg := &model.Package{
+		Name:       "store",
+		Interfaces: []*model.Interface{{Name: "Reader", Methods: []*model.Method{{Name: "Read"}}}},
+	}
+	want := "func (m *MockReader) LoadFromJSON(path string) error {\n\treturn gomock.LoadReturnsFromJSON(m, m.recorder, path)\n}\n"
+
+	g := generator{filename: "store.go"}
+	if err := g.Generate(pkg, "mock_store", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out := string(g.Output()); strings.Contains(out, "LoadFromJSON") {
+		t.Errorf("generated code has a LoadFromJSON method without -json_stubs:\n%s", out)
+	}
+
+	g = generator{jsonStubs: true, filename: "store.go"}
+	if err := g.Generate(pkg, "mock_store", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out := string(g.Output()); !strings.Contains(out, want) {
+		t.Errorf("generated code does not contain %q:\n%s", want, out)
+	}
+}
+
+func TestGenerate_AdapterErrors(t *testing.T) {
+	str := model.PredeclaredType("string")
+	pkg := &model.Package{
+		Name:    "store",
+		PkgPath: "example.com/store",
+		Interfaces: []*model.Interface{
+			{Name: "Reader", Methods: []*model.Method{{Name: "Read", In: []*model.Parameter{{Type: str}}}}},
+			{Name: "IntReader", Methods: []*model.Method{{Name: "Read", In: []*model.Parameter{{Type: model.PredeclaredType("int")}}}}},
+			{Name: "ReadCloser", Methods: []*model.Method{{Name: "Read", In: []*model.Parameter{{Type: str}}}, {Name: "Close"}}},
+		},
+	}
+
+	for _, tt := range []struct {
+		from, to, want string
+	}{
+		{"Reader", "IntReader", "cannot adapt Reader to IntReader: method Read is func(string) in Reader, but func(int) in IntReader"},
+		{"Reader", "ReadCloser", "cannot adapt Reader to ReadCloser: Reader has no method Close"},
+		{"Reader", "Writer", "cannot adapt Reader to Writer: unknown interface Writer"},
+	} {
+		g := generator{adapters: []adapterPair{{tt.from, tt.to}}, filename: "store.go"}
+		err := g.Generate(pkg, "store", pkg.PkgPath)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("Generate() with adapter %v=%v: error = %v, want %q", tt.from, tt.to, err, tt.want)
+		}
+	}
+
+	// A subset of the methods can be adapted.
+	g := generator{adapters: []adapterPair{{"ReadCloser", "Reader"}}, filename: "store.go"}
+	if err := g.Generate(pkg, "store", pkg.PkgPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out := string(g.Output()); !strings.Contains(out, "func (a *ReadCloserAsReader) Read(arg0 string) {\n\ta.adapted.Read(arg0)\n}") {
+		t.Errorf("generated code does not delegate Read:\n%s", out)
+	}
+}
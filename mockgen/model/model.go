@@ -32,6 +32,9 @@ type Package struct {
 	PkgPath    string
 	Interfaces []*Interface
 	DotImports []string
+	// BuildConstraint is the build constraint of the source file, such as
+	// "linux && amd64", in source mode; it is empty otherwise.
+	BuildConstraint string
 }
 
 // Print writes the package name and its exported interfaces.
@@ -53,19 +56,29 @@ func (pkg *Package) Imports() map[string]bool {
 
 // Interface is a Go interface.
 type Interface struct {
-	Name    string
-	Methods []*Method
+	Name       string
+	Methods    []*Method
+	TypeParams []*Parameter // the type parameters of a generic interface and their constraints; source mode only
 }
 
 // Print writes the interface name and its methods.
 func (intf *Interface) Print(w io.Writer) {
 	_, _ = fmt.Fprintf(w, "interface %s\n", intf.Name)
+	if len(intf.TypeParams) > 0 {
+		_, _ = fmt.Fprintf(w, "    type parameters:\n")
+		for _, p := range intf.TypeParams {
+			p.Print(w)
+		}
+	}
 	for _, m := range intf.Methods {
 		m.Print(w)
 	}
 }
 
 func (intf *Interface) addImports(im map[string]bool) {
+	for _, p := range intf.TypeParams {
+		p.Type.addImports(im)
+	}
 	for _, m := range intf.Methods {
 		m.addImports(im)
 	}
@@ -73,9 +86,11 @@ func (intf *Interface) addImports(im map[string]bool) {
 
 // Method is a single method of an interface.
 type Method struct {
-	Name     string
-	In, Out  []*Parameter
-	Variadic *Parameter // may be nil
+	Name       string
+	In, Out    []*Parameter
+	Variadic   *Parameter // may be nil
+	Deprecated string     // the "Deprecated: " paragraph of the method's doc comment; source mode only
+	Origin     string     // the embedded interface the method comes from, if any; source mode only
 }
 
 // Print writes the method name and its signature.
@@ -180,6 +195,10 @@ func (ct *ChanType) String(pm map[string]string, pkgOverride string) string {
 	if ct.Dir == SendDir {
 		return "chan<- " + s
 	}
+	// "chan <-chan T" would be read as "chan<- (chan T)".
+	if elem, ok := ct.Type.(*ChanType); ok && elem.Dir == RecvDir {
+		return "chan (" + s + ")"
+	}
 	return "chan " + s
 }
 
@@ -249,27 +268,56 @@ func (mt *MapType) addImports(im map[string]bool) {
 
 // NamedType is an exported type in a package.
 type NamedType struct {
-	Package string // may be empty
-	Type    string // TODO: should this be typed Type?
+	Package    string              // may be empty
+	Type       string              // TODO: should this be typed Type?
+	TypeParams *TypeParametersType // the type arguments of an instantiated generic type; may be nil
 }
 
 func (nt *NamedType) String(pm map[string]string, pkgOverride string) string {
 	// TODO: is this right?
 	if pkgOverride == nt.Package {
-		return nt.Type
+		return nt.Type + nt.TypeParams.String(pm, pkgOverride)
 	}
 	prefix := pm[nt.Package]
 	if prefix != "" {
-		return prefix + "." + nt.Type
+		return prefix + "." + nt.Type + nt.TypeParams.String(pm, pkgOverride)
 	}
 
-	return nt.Type
+	return nt.Type + nt.TypeParams.String(pm, pkgOverride)
 }
 
 func (nt *NamedType) addImports(im map[string]bool) {
 	if nt.Package != "" {
 		im[nt.Package] = true
 	}
+	nt.TypeParams.addImports(im)
+}
+
+// TypeParametersType is the list of type arguments of an instantiated generic
+// type, such as [string, T] in Pair[string, T].
+type TypeParametersType struct {
+	TypeParameters []Type
+}
+
+// String returns the type arguments in brackets, or "" if tp is nil.
+func (tp *TypeParametersType) String(pm map[string]string, pkgOverride string) string {
+	if tp == nil || len(tp.TypeParameters) == 0 {
+		return ""
+	}
+	args := make([]string, len(tp.TypeParameters))
+	for i, t := range tp.TypeParameters {
+		args[i] = t.String(pm, pkgOverride)
+	}
+	return "[" + strings.Join(args, ", ") + "]"
+}
+
+func (tp *TypeParametersType) addImports(im map[string]bool) {
+	if tp == nil {
+		return
+	}
+	for _, t := range tp.TypeParameters {
+		t.addImports(im)
+	}
 }
 
 // PointerType is a pointer to another type.
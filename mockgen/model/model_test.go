@@ -34,3 +34,24 @@ func TestImpPath(t *testing.T) {
 		})
 	}
 }
+
+func TestChanTypeString(t *testing.T) {
+	elem := PredeclaredType("int")
+	testCases := []struct {
+		ct   *ChanType
+		want string
+	}{
+		{&ChanType{Type: elem}, "chan int"},
+		{&ChanType{Dir: RecvDir, Type: elem}, "<-chan int"},
+		{&ChanType{Dir: SendDir, Type: elem}, "chan<- int"},
+		{&ChanType{Type: &ChanType{Dir: RecvDir, Type: elem}}, "chan (<-chan int)"},
+		{&ChanType{Type: &ChanType{Dir: SendDir, Type: elem}}, "chan chan<- int"},
+		{&ChanType{Dir: SendDir, Type: &ChanType{Dir: RecvDir, Type: elem}}, "chan<- <-chan int"},
+		{&ChanType{Dir: RecvDir, Type: &ChanType{Dir: RecvDir, Type: elem}}, "<-chan <-chan int"},
+	}
+	for _, tc := range testCases {
+		if got := tc.ct.String(nil, ""); got != tc.want {
+			t.Errorf("String() = %q, want %q", got, tc.want)
+		}
+	}
+}
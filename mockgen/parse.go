@@ -26,6 +26,7 @@ import (
 	"go/token"
 	"io/ioutil"
 	"log"
+	"os"
 	"path"
 	"path/filepath"
 	"strconv"
@@ -55,7 +56,7 @@ func sourceMode(source string) (*model.Package, error) {
 	}
 
 	fs := token.NewFileSet()
-	file, err := parser.ParseFile(fs, source, nil, 0)
+	file, err := parser.ParseFile(fs, source, nil, parser.ParseComments)
 	if err != nil {
 		return nil, fmt.Errorf("failed parsing source file %v: %v", source, err)
 	}
@@ -88,8 +89,19 @@ func sourceMode(source string) (*model.Package, error) {
 	if err := p.parseAuxFiles(*auxFiles); err != nil {
 		return nil, err
 	}
+	if err := p.parseSiblingFiles(source, packageImport, file.Name.Name); err != nil {
+		return nil, err
+	}
 	p.addAuxInterfacesFromFile(packageImport, file) // this file
 
+	// Resolve the types of dot imports, which are not qualified in the source.
+	_, fileDotImports := importsOfFile(file)
+	for pkgPath := range dotImports {
+		fileDotImports = append(fileDotImports, pkgPath)
+	}
+	p.srcPackage = packageImport
+	p.resolveDotImports(fileDotImports)
+
 	pkg, err := p.parseFile(packageImport, file)
 	if err != nil {
 		return nil, err
@@ -97,9 +109,171 @@ func sourceMode(source string) (*model.Package, error) {
 	for pkgPath := range dotImports {
 		pkg.DotImports = append(pkg.DotImports, pkgPath)
 	}
+	pkg.DotImports = p.usedDotImports(pkg)
+	if pkg.BuildConstraint, err = buildConstraint(file); err != nil {
+		return nil, fmt.Errorf("failed parsing build constraint of %v: %v", source, err)
+	}
 	return pkg, nil
 }
 
+// buildConstraint returns the build constraint of file, given by a //go:build
+// line or, failing that, by // +build lines, or "" if it has none.
+func buildConstraint(file *ast.File) (string, error) {
+	var plusBuild []buildExpr
+	for _, cg := range file.Comments {
+		// Constraints must precede the package clause.
+		if cg.Pos() > file.Package {
+			break
+		}
+		for _, c := range cg.List {
+			switch {
+			case isGoBuild(c.Text):
+				expr, err := parseGoBuild(strings.TrimSpace(c.Text)[len("//go:build"):])
+				if err != nil {
+					return "", err
+				}
+				return expr.String(), nil
+			case isPlusBuild(c.Text):
+				expr, err := parsePlusBuild(c.Text)
+				if err != nil {
+					return "", err
+				}
+				plusBuild = append(plusBuild, expr)
+			}
+		}
+	}
+	if len(plusBuild) == 0 {
+		return "", nil
+	}
+	// Multiple +build lines must all be satisfied.
+	expr := plusBuild[0]
+	for _, x := range plusBuild[1:] {
+		expr = andExpr{expr, x}
+	}
+	return expr.String(), nil
+}
+
+// resolveDotImports records which of the dot imports with the given import
+// paths declares each of their exported types. Dot imports that cannot be
+// loaded are skipped, so their types are left unqualified.
+func (p *fileParser) resolveDotImports(importPaths []string) {
+	p.dotTypes = make(map[string]string)
+	for _, pkgPath := range importPaths {
+		names, err := p.exportedTypeNames(pkgPath)
+		if err != nil {
+			continue
+		}
+		for _, name := range names {
+			p.dotTypes[name] = pkgPath
+		}
+	}
+}
+
+// usedDotImports returns the dot imports of pkg that declare at least one of
+// the unqualified types used by its interfaces. The generated code must not
+// include the other dot imports since it would not compile with unused
+// imports. Dot imports that cannot be loaded are assumed to be used.
+func (p *fileParser) usedDotImports(pkg *model.Package) []string {
+	if len(pkg.DotImports) == 0 {
+		return pkg.DotImports
+	}
+
+	unqualified := make(map[string]bool)
+	for _, intf := range pkg.Interfaces {
+		for _, m := range intf.Methods {
+			walkNamedTypes(m, func(nt *model.NamedType) {
+				if nt.Package == pkg.PkgPath {
+					unqualified[nt.Type] = true
+				}
+			})
+		}
+	}
+
+	used := make([]string, 0, len(pkg.DotImports))
+	for _, pkgPath := range pkg.DotImports {
+		names, err := p.exportedTypeNames(pkgPath)
+		if err != nil {
+			used = append(used, pkgPath)
+			continue
+		}
+		for _, name := range names {
+			if unqualified[name] {
+				used = append(used, pkgPath)
+				break
+			}
+		}
+	}
+	return used
+}
+
+// exportedTypeNames returns the names of the exported types declared by the
+// package with the given import path.
+func (p *fileParser) exportedTypeNames(importPath string) ([]string, error) {
+	imp, err := build.Import(importPath, p.srcDir, build.FindOnly)
+	if err != nil {
+		return nil, err
+	}
+	notTest := func(fi os.FileInfo) bool { return !strings.HasSuffix(fi.Name(), "_test.go") }
+	pkgs, err := parser.ParseDir(token.NewFileSet(), imp.Dir, notTest, 0)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gd, ok := decl.(*ast.GenDecl)
+				if !ok || gd.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gd.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok && ts.Name.IsExported() {
+						names = append(names, ts.Name.Name)
+					}
+				}
+			}
+		}
+	}
+	return names, nil
+}
+
+// walkNamedTypes calls fn for every named type in the signature of m,
+// including those nested in composite types.
+func walkNamedTypes(m *model.Method, fn func(*model.NamedType)) {
+	var walk func(t model.Type)
+	walkParams := func(ps []*model.Parameter) {
+		for _, p := range ps {
+			walk(p.Type)
+		}
+	}
+	walk = func(t model.Type) {
+		switch t := t.(type) {
+		case *model.ArrayType:
+			walk(t.Type)
+		case *model.ChanType:
+			walk(t.Type)
+		case *model.FuncType:
+			walkParams(t.In)
+			walkParams(t.Out)
+			if t.Variadic != nil {
+				walk(t.Variadic.Type)
+			}
+		case *model.MapType:
+			walk(t.Key)
+			walk(t.Value)
+		case *model.NamedType:
+			fn(t)
+		case *model.PointerType:
+			walk(t.Type)
+		}
+	}
+	walkParams(m.In)
+	walkParams(m.Out)
+	if m.Variadic != nil {
+		walk(m.Variadic.Type)
+	}
+}
+
 type fileParser struct {
 	fileSet            *token.FileSet
 	imports            map[string]string                        // package name => import path
@@ -108,7 +282,10 @@ type fileParser struct {
 	auxFiles      []*ast.File
 	auxInterfaces map[string]map[string]*ast.InterfaceType // package (or "") => name => interface
 
-	srcDir string
+	srcDir     string
+	srcPackage string            // import path of the source file's package
+	dotTypes   map[string]string // type name => import path of the source file's dot import declaring it
+	typeParams map[string]bool   // names of the type parameters of the generic interface being parsed
 }
 
 func (p *fileParser) errorf(pos token.Pos, format string, args ...interface{}) error {
@@ -130,7 +307,7 @@ func (p *fileParser) parseAuxFiles(auxFiles string) error {
 		}
 		pkg, fpath := parts[0], parts[1]
 
-		file, err := parser.ParseFile(p.fileSet, fpath, nil, 0)
+		file, err := parser.ParseFile(p.fileSet, fpath, nil, parser.ParseComments)
 		if err != nil {
 			return err
 		}
@@ -140,6 +317,40 @@ func (p *fileParser) parseAuxFiles(auxFiles string) error {
 	return nil
 }
 
+// parseSiblingFiles parses the other non-test files of package pkgName, with
+// import path packageImport, in the directory of the source file, so that
+// interfaces embedded from them can be resolved. Files excluded by build
+// constraints are skipped.
+func (p *fileParser) parseSiblingFiles(source, packageImport, pkgName string) error {
+	srcPath, err := filepath.Abs(source)
+	if err != nil {
+		return err
+	}
+	paths, err := filepath.Glob(filepath.Join(p.srcDir, "*.go"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		name := filepath.Base(path)
+		if path == srcPath || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if ok, err := build.Default.MatchFile(p.srcDir, name); err != nil || !ok {
+			continue
+		}
+		file, err := parser.ParseFile(p.fileSet, path, nil, parser.ParseComments)
+		if err != nil {
+			return fmt.Errorf("failed parsing sibling file %v: %v", path, err)
+		}
+		if file.Name.Name != pkgName {
+			continue
+		}
+		p.auxFiles = append(p.auxFiles, file)
+		p.addAuxInterfacesFromFile(packageImport, file)
+	}
+	return nil
+}
+
 func (p *fileParser) addAuxInterfacesFromFile(pkg string, file *ast.File) {
 	if _, ok := p.auxInterfaces[pkg]; !ok {
 		p.auxInterfaces[pkg] = make(map[string]*ast.InterfaceType)
@@ -172,10 +383,16 @@ func (p *fileParser) parseFile(importPath string, file *ast.File) (*model.Packag
 
 	var is []*model.Interface
 	for ni := range iterInterfaces(file) {
+		tps, err := p.parseTypeParams(importPath, ni.typeParams)
+		if err != nil {
+			return nil, err
+		}
 		i, err := p.parseInterface(ni.name.String(), importPath, ni.it)
+		p.typeParams = nil
 		if err != nil {
 			return nil, err
 		}
+		i.TypeParams = tps
 		is = append(is, i)
 	}
 	return &model.Package{
@@ -192,7 +409,7 @@ func (p *fileParser) parsePackage(path string) error {
 	var pkgs map[string]*ast.Package
 	if imp, err := build.Import(path, p.srcDir, build.FindOnly); err != nil {
 		return err
-	} else if pkgs, err = parser.ParseDir(p.fileSet, imp.Dir, nil, 0); err != nil {
+	} else if pkgs, err = parser.ParseDir(p.fileSet, imp.Dir, nil, parser.ParseComments); err != nil {
 		return err
 	}
 	for _, pkg := range pkgs {
@@ -213,6 +430,41 @@ func (p *fileParser) parsePackage(path string) error {
 	return nil
 }
 
+// deprecationNotice returns the paragraph of doc starting with "Deprecated: ",
+// which marks the documented identifier as deprecated, or "" if there is none.
+func deprecationNotice(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	for _, para := range strings.Split(doc.Text(), "\n\n") {
+		if strings.HasPrefix(para, "Deprecated: ") {
+			return strings.TrimSpace(para)
+		}
+	}
+	return ""
+}
+
+// parseTypeParams returns the type parameters of a generic interface with
+// their constraints, and records their names so that parseType tells them
+// apart from named types until p.typeParams is reset.
+func (p *fileParser) parseTypeParams(pkg string, fields []*ast.Field) ([]*model.Parameter, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	p.typeParams = make(map[string]bool)
+	for _, f := range fields {
+		for _, name := range f.Names {
+			p.typeParams[name.Name] = true
+		}
+	}
+	tps, err := p.parseFieldList(pkg, fields)
+	if err != nil {
+		p.typeParams = nil
+		return nil, p.errorf(fields[0].Pos(), "failed parsing type parameters: %v", err)
+	}
+	return tps, nil
+}
+
 func (p *fileParser) parseInterface(name, pkg string, it *ast.InterfaceType) (*model.Interface, error) {
 	intf := &model.Interface{Name: name}
 	for _, field := range it.Methods.List {
@@ -222,7 +474,8 @@ func (p *fileParser) parseInterface(name, pkg string, it *ast.InterfaceType) (*m
 				return nil, fmt.Errorf("expected one name for interface %v, got %d", intf.Name, nn)
 			}
 			m := &model.Method{
-				Name: field.Names[0].String(),
+				Name:       field.Names[0].String(),
+				Deprecated: deprecationNotice(field.Doc),
 			}
 			var err error
 			m.In, m.Variadic, m.Out, err = p.parseFunc(pkg, v)
@@ -231,19 +484,29 @@ func (p *fileParser) parseInterface(name, pkg string, it *ast.InterfaceType) (*m
 			}
 			intf.Methods = append(intf.Methods, m)
 		case *ast.Ident:
-			// Embedded interface in this package.
+			// Embedded interface in this package, or in a dot import.
+			epkg := pkg
 			ei := p.auxInterfaces[pkg][v.String()]
 			if ei == nil {
-				if ei = p.importedInterfaces[pkg][v.String()]; ei == nil {
+				if dotPkg, ok := p.dotImportOf(pkg, v.Name); ok {
+					if _, ok := p.importedInterfaces[dotPkg]; !ok {
+						if err := p.parsePackage(dotPkg); err != nil {
+							return nil, p.errorf(v.Pos(), "could not parse package %s: %v", dotPkg, err)
+						}
+					}
+					epkg = dotPkg
+				}
+				if ei = p.importedInterfaces[epkg][v.String()]; ei == nil {
 					return nil, p.errorf(v.Pos(), "unknown embedded interface %s", v.String())
 				}
 			}
-			eintf, err := p.parseInterface(v.String(), pkg, ei)
+			eintf, err := p.parseInterface(v.String(), epkg, ei)
 			if err != nil {
 				return nil, err
 			}
 			// Copy the methods.
 			// TODO: apply shadowing rules.
+			setOrigin(eintf.Methods, v.String())
 			intf.Methods = append(intf.Methods, eintf.Methods...)
 		case *ast.SelectorExpr:
 			// Embedded interface in another package.
@@ -270,6 +533,7 @@ func (p *fileParser) parseInterface(name, pkg string, it *ast.InterfaceType) (*m
 			}
 			// Copy the methods.
 			// TODO: apply shadowing rules.
+			setOrigin(eintf.Methods, v.X.(*ast.Ident).String()+"."+sel)
 			intf.Methods = append(intf.Methods, eintf.Methods...)
 		default:
 			return nil, fmt.Errorf("don't know how to mock method of type %T", field.Type)
@@ -278,6 +542,25 @@ func (p *fileParser) parseInterface(name, pkg string, it *ast.InterfaceType) (*m
 	return intf, nil
 }
 
+// setOrigin records that methods come from the embedded interface origin,
+// spelled as in the embedding interface. Methods of interfaces embedded in
+// turn end up attributed to the outermost embedded interface.
+func setOrigin(methods []*model.Method, origin string) {
+	for _, m := range methods {
+		m.Origin = origin
+	}
+}
+
+// dotImportOf returns the import path of the dot import of the source file
+// that declares the type name, if name is used unqualified in pkg.
+func (p *fileParser) dotImportOf(pkg, name string) (string, bool) {
+	if pkg != p.srcPackage {
+		return "", false
+	}
+	dotPkg, ok := p.dotTypes[name]
+	return dotPkg, ok
+}
+
 func (p *fileParser) parseFunc(pkg string, f *ast.FuncType) (in []*model.Parameter, variadic *model.Parameter, out []*model.Parameter, err error) {
 	if f.Params != nil {
 		regParams := f.Params.List
@@ -378,6 +661,13 @@ func (p *fileParser) parseType(pkg string, typ ast.Expr) (model.Type, error) {
 		}
 		return &model.FuncType{In: in, Out: out, Variadic: variadic}, nil
 	case *ast.Ident:
+		if p.typeParams[v.Name] {
+			// A type parameter is spelled the same in the mock.
+			return model.PredeclaredType(v.Name), nil
+		}
+		if dotPkg, ok := p.dotImportOf(pkg, v.Name); ok {
+			return &model.NamedType{Package: dotPkg, Type: v.Name}, nil
+		}
 		if v.IsExported() {
 			// `pkg` may be an aliased imported pkg
 			// if so, patch the import w/ the fully qualified import
@@ -406,6 +696,9 @@ func (p *fileParser) parseType(pkg string, typ ast.Expr) (model.Type, error) {
 			return nil, err
 		}
 		return &model.MapType{Key: key, Value: value}, nil
+	case *ast.ParenExpr:
+		// e.g. chan (<-chan int)
+		return p.parseType(pkg, v.X)
 	case *ast.SelectorExpr:
 		pkgName := v.X.(*ast.Ident).String()
 		pkg, ok := p.imports[pkgName]
@@ -426,6 +719,9 @@ func (p *fileParser) parseType(pkg string, typ ast.Expr) (model.Type, error) {
 		return model.PredeclaredType("struct{}"), nil
 	}
 
+	if t, err := p.parseGenericType(pkg, typ); t != nil || err != nil {
+		return t, err
+	}
 	return nil, fmt.Errorf("don't know how to parse type %T", typ)
 }
 
@@ -471,8 +767,9 @@ func importsOfFile(file *ast.File) (normalImports map[string]string, dotImports
 }
 
 type namedInterface struct {
-	name *ast.Ident
-	it   *ast.InterfaceType
+	name       *ast.Ident
+	it         *ast.InterfaceType
+	typeParams []*ast.Field // nil unless the interface is generic
 }
 
 // Create an iterator over all interfaces in file.
@@ -494,7 +791,7 @@ func iterInterfaces(file *ast.File) <-chan namedInterface {
 					continue
 				}
 
-				ch <- namedInterface{ts.Name, it}
+				ch <- namedInterface{ts.Name, it, getTypeSpecTypeParams(ts)}
 			}
 		}
 		close(ch)
@@ -4,6 +4,8 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -113,3 +115,196 @@ func Benchmark_parseFile(b *testing.B) {
 		sourceMode(source)
 	}
 }
+
+func TestSourceMode_DeepEmbedding(t *testing.T) {
+	pkg, err := sourceMode("internal/tests/deep_embedding/input.go")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string][]string{
+		"Base":     {"Close"},
+		"Middle":   {"Close", "Name"},
+		"Top":      {"Close", "Name", "Run"},
+		"Imported": {"Zero", "One", "Two", "Three"},
+	}
+	for _, intf := range pkg.Interfaces {
+		var methods []string
+		for _, m := range intf.Methods {
+			methods = append(methods, m.Name)
+		}
+		if want := expected[intf.Name]; !reflect.DeepEqual(methods, want) {
+			t.Errorf("methods of %v = %v, want %v", intf.Name, methods, want)
+		}
+		delete(expected, intf.Name)
+	}
+	for name := range expected {
+		t.Errorf("interface %v not parsed", name)
+	}
+}
+
+func TestSourceMode_DirectiveComments(t *testing.T) {
+	pkg, err := sourceMode("internal/tests/directive_comments/input.go")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	g := generator{filename: "input.go"}
+	if err := g.Generate(pkg, "directive_comments", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, line := range strings.Split(string(g.Output()), "\n") {
+		if strings.Contains(line, "//nolint") || strings.Contains(line, "//lint:") {
+			t.Errorf("generated code has directive %q", line)
+		}
+	}
+}
+
+func TestSourceMode_Deprecated(t *testing.T) {
+	pkg, err := sourceMode("internal/tests/deprecated/input.go")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	g := generator{filename: "input.go"}
+	if err := g.Generate(pkg, "deprecated", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := string(g.Output())
+	for _, want := range []string{
+		"// Fetch mocks base method\n//\n// Deprecated: Fetch does not report errors; use Get\n// instead.\nfunc (m *MockStore) Fetch(",
+		"// Lookup mocks base method\n//\n// Deprecated: use Get.\nfunc (m *MockStore) Lookup(",
+		"// Get mocks base method\nfunc (m *MockStore) Get(",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestSourceMode_EmbedOrigins(t *testing.T) {
+	pkg, err := sourceMode("internal/tests/embed_origins/input.go")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	g := generator{embedOrigins: true, filename: "input.go"}
+	if err := g.Generate(pkg, "embed_origins", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := string(g.Output())
+	for _, want := range []string{
+		"// Read mocks base method\n// from io.ReadCloser\nfunc (m *MockStream) Read(",
+		"// Close mocks base method\n// from io.ReadCloser\nfunc (m *MockStream) Close(",
+		"// Write mocks base method\n// from Writer\nfunc (m *MockStream) Write(",
+		"// Flush mocks base method\nfunc (m *MockStream) Flush(",
+		"// Write mocks base method\nfunc (m *MockWriter) Write(",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+
+	g = generator{filename: "input.go"}
+	if err := g.Generate(pkg, "embed_origins", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out := string(g.Output()); strings.Contains(out, "// from ") {
+		t.Errorf("generated code has origin comments without -embed_origins:\n%s", out)
+	}
+}
+
+func TestSourceMode_DotImports(t *testing.T) {
+	pkg, err := sourceMode("internal/tests/dot_imports/input.go")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(pkg.DotImports) != 0 {
+		t.Errorf("DotImports = %v, want none since all their types are resolved", pkg.DotImports)
+	}
+
+	g := generator{filename: "input.go"}
+	if err := g.Generate(pkg, "mock_dot_imports", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := string(g.Output())
+	for _, want := range []string{
+		"Method1() http.Request {",
+		"Method3() context.Context {",
+		"func (m *MockWithDotImportedEmbed) Deadline() (",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestSourceMode_BuildConstraint(t *testing.T) {
+	pkg, err := sourceMode("internal/tests/build_constraint/input.go")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := "!plan9"; pkg.BuildConstraint != want {
+		t.Errorf("BuildConstraint = %q, want %q", pkg.BuildConstraint, want)
+	}
+
+	g := generator{buildTag: "mocks || test", filename: "input.go"}
+	if err := g.Generate(pkg, "build_constraint", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := string(g.Output())
+	want := "//go:build !plan9 && (mocks || test)\n// +build !plan9\n// +build mocks test\n\n// Code generated by MockGen. DO NOT EDIT.\n"
+	if !strings.HasPrefix(out, want) {
+		t.Errorf("generated code does not start with %q:\n%s", want, out)
+	}
+}
+
+func TestBuildConstraint(t *testing.T) {
+	for _, tt := range []struct {
+		src, want string
+	}{
+		{"package p\n", ""},
+		{"//go:build linux && amd64\n\npackage p\n", "linux && amd64"},
+		{"// Copyright\n\n//go:build linux\n// +build linux\n\n// Package p is p.\npackage p\n", "linux"},
+		{"// +build linux darwin\n// +build amd64\n\npackage p\n", "(linux || darwin) && amd64"},
+		{"//go:build windows\n// +build linux\n\npackage p\n", "windows"},
+		{"package p\n\n//go:build linux\n", ""},
+	} {
+		fs := token.NewFileSet()
+		file, err := parser.ParseFile(fs, "p.go", tt.src, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		got, err := buildConstraint(file)
+		if err != nil {
+			t.Errorf("buildConstraint(%q) error: %v", tt.src, err)
+		} else if got != tt.want {
+			t.Errorf("buildConstraint(%q) = %q, want %q", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestSourceMode_SiblingEmbed(t *testing.T) {
+	pkg, err := sourceMode("internal/tests/sibling_embed/input.go")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(pkg.Interfaces) != 1 {
+		t.Fatalf("Interfaces = %v, want only Store", pkg.Interfaces)
+	}
+	var methods []string
+	for _, m := range pkg.Interfaces[0].Methods {
+		methods = append(methods, m.Name)
+	}
+	if want := []string{"Get", "Put", "Close"}; !reflect.DeepEqual(methods, want) {
+		t.Errorf("methods of Store = %v, want %v", methods, want)
+	}
+}
+
+func TestParseGoBuild_Invalid(t *testing.T) {
+	for _, src := range []string{"", "mocks &&", "(a || b", "a b", "a-b", "!", "a || || b"} {
+		if x, err := parseGoBuild(src); err == nil {
+			t.Errorf("parseGoBuild(%q) = %q, want error", src, x)
+		}
+	}
+}
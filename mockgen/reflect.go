@@ -153,9 +153,19 @@ func reflectMode(importPath string, symbols []string) (*model.Package, error) {
 
 	wd, _ := os.Getwd()
 
-	// Try to run the reflection program  in the current working directory.
-	if p, err := runInDir(program, wd); err == nil {
-		return p, nil
+	// A main package cannot be imported, so reflect on it from a test of the
+	// package instead.
+	if p, err := build.Import(importPath, wd, 0); err == nil && p.Name == "main" {
+		return reflectMainPackage(importPath, symbols, p.Dir)
+	}
+
+	// Try to run the reflection program  in the current working directory,
+	// unless the input is an internal package that cannot be imported from
+	// there.
+	if canImport(packagePathOfDir(wd), importPath) {
+		if p, err := runInDir(program, wd); err == nil {
+			return p, nil
+		}
 	}
 
 	// Try to run the program in the same directory as the input package.
@@ -170,6 +180,59 @@ func reflectMode(importPath string, symbols []string) (*model.Package, error) {
 	return runInDir(program, "")
 }
 
+// reflectMainPackage reflects on the interfaces of the main package in dir by
+// building a test binary of the package, whose TestMain runs the reflection.
+func reflectMainPackage(importPath string, symbols []string, dir string) (*model.Package, error) {
+	var program bytes.Buffer
+	data := reflectData{
+		ImportPath: importPath,
+		Symbols:    symbols,
+	}
+	if err := reflectMainProgram.Execute(&program, &data); err != nil {
+		return nil, err
+	}
+
+	f, err := ioutil.TempFile(dir, "gomock_reflect_*_test.go")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(program.Bytes()); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := ioutil.TempDir("", "gomock_reflect_")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+	progBinary := filepath.Join(tmpDir, "prog.test")
+	if runtime.GOOS == "windows" {
+		progBinary += ".exe"
+	}
+
+	cmdArgs := []string{"test", "-c"}
+	if *buildFlags != "" {
+		cmdArgs = append(cmdArgs, strings.Split(*buildFlags, " ")...)
+	}
+	cmdArgs = append(cmdArgs, "-o", progBinary, ".")
+
+	// Build the test binary.
+	cmd := exec.Command("go", cmdArgs...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+
+	return run(progBinary)
+}
+
 type reflectData struct {
 	ImportPath string
 	Symbols    []string
@@ -245,3 +308,69 @@ func main() {
 	}
 }
 `))
+
+// reflectMainProgram is like reflectProgram, but is added to the tests of a
+// main package, which it reflects on from TestMain. Its identifiers are
+// prefixed to avoid conflicts with those of the package.
+var reflectMainProgram = template.Must(template.New("program").Parse(`
+package main
+
+import (
+	gomock_reflect_gob "encoding/gob"
+	gomock_reflect_flag "flag"
+	gomock_reflect_fmt "fmt"
+	gomock_reflect_os "os"
+	gomock_reflect_reflect "reflect"
+	gomock_reflect_testing "testing"
+
+	gomock_reflect_model "github.com/golang/mock/mockgen/model"
+)
+
+func TestMain(*gomock_reflect_testing.M) {
+	output := gomock_reflect_flag.String("output", "", "The output file name, or empty to use stdout.")
+	gomock_reflect_flag.Parse()
+
+	its := []struct{
+		sym string
+		typ gomock_reflect_reflect.Type
+	}{
+		{{range .Symbols}}
+		{ {{printf "%q" .}}, gomock_reflect_reflect.TypeOf((*{{.}})(nil)).Elem()},
+		{{end}}
+	}
+	pkg := &gomock_reflect_model.Package{
+		Name:    "main",
+		PkgPath: {{printf "%q" .ImportPath}},
+	}
+
+	for _, it := range its {
+		intf, err := gomock_reflect_model.InterfaceFromInterfaceType(it.typ)
+		if err != nil {
+			gomock_reflect_fmt.Fprintf(gomock_reflect_os.Stderr, "Reflection: %v\n", err)
+			gomock_reflect_os.Exit(1)
+		}
+		intf.Name = it.sym
+		pkg.Interfaces = append(pkg.Interfaces, intf)
+	}
+
+	outfile := gomock_reflect_os.Stdout
+	if len(*output) != 0 {
+		var err error
+		outfile, err = gomock_reflect_os.Create(*output)
+		if err != nil {
+			gomock_reflect_fmt.Fprintf(gomock_reflect_os.Stderr, "failed to open output file %q", *output)
+			gomock_reflect_os.Exit(1)
+		}
+	}
+
+	if err := gomock_reflect_gob.NewEncoder(outfile).Encode(pkg); err != nil {
+		gomock_reflect_fmt.Fprintf(gomock_reflect_os.Stderr, "gob encode: %v\n", err)
+		gomock_reflect_os.Exit(1)
+	}
+	if err := outfile.Close(); err != nil {
+		gomock_reflect_fmt.Fprintf(gomock_reflect_os.Stderr, "failed to close output file %q", *output)
+		gomock_reflect_os.Exit(1)
+	}
+	gomock_reflect_os.Exit(0)
+}
+`))
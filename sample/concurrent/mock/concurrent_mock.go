@@ -11,7 +11,7 @@ import (
 
 // MockMath is a mock of Math interface
 type MockMath struct {
-	ctrl     *gomock.Controller
+	ctrl     gomock.ControllerInterface
 	recorder *MockMathMockRecorder
 }
 
@@ -21,7 +21,7 @@ type MockMathMockRecorder struct {
 }
 
 // NewMockMath creates a new mock instance
-func NewMockMath(ctrl *gomock.Controller) *MockMath {
+func NewMockMath(ctrl gomock.ControllerInterface) *MockMath {
 	mock := &MockMath{ctrl: ctrl}
 	mock.recorder = &MockMathMockRecorder{mock}
 	return mock
@@ -34,7 +34,7 @@ func (m *MockMath) EXPECT() *MockMathMockRecorder {
 
 // Sum mocks base method
 func (m *MockMath) Sum(arg0, arg1 int) int {
-	m.ctrl.T.Helper()
+	m.ctrl.TestHelper().Helper()
 	ret := m.ctrl.Call(m, "Sum", arg0, arg1)
 	ret0, _ := ret[0].(int)
 	return ret0
@@ -42,6 +42,6 @@ func (m *MockMath) Sum(arg0, arg1 int) int {
 
 // Sum indicates an expected call of Sum
 func (mr *MockMathMockRecorder) Sum(arg0, arg1 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
+	mr.mock.ctrl.TestHelper().Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sum", reflect.TypeOf((*MockMath)(nil).Sum), arg0, arg1)
 }